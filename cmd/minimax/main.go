@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -10,10 +12,23 @@ import (
 	"minimax/internal/app"
 	"minimax/internal/config"
 	"minimax/internal/logging"
+	"minimax/internal/minimax"
+	"minimax/internal/minimax/minimaxserver"
 	"minimax/internal/system"
 )
 
 func main() {
+	// 隐藏命令：启动本地 MiniMax 替身服务，便于无凭证演示与验收。
+	if len(os.Args) > 1 && os.Args[1] == "fake-server" {
+		runFakeServer(os.Args[2:])
+		return
+	}
+
+	baseURL := flag.String("base-url", "", "MiniMax API 根地址，优先于 region（环境变量 "+config.EnvBaseURL+"）")
+	region := flag.String("region", "", "MiniMax 区域预设：cn 或 global（环境变量 "+config.EnvRegion+"）")
+	trace := flag.Bool("trace", false, "将脱敏后的 HTTP 请求与响应写入日志文件（环境变量 "+config.EnvTrace+"）")
+	flag.Parse()
+
 	zerolog.TimeFieldFormat = time.RFC3339
 
 	paths, err := system.ResolvePaths()
@@ -33,6 +48,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	cfg = config.Resolve(cfg, config.Overrides{Region: *region, BaseURL: *baseURL, Trace: *trace})
+	if _, err := minimax.ResolveBaseURL(cfg.BaseURL, cfg.Region); err != nil {
+		fmt.Fprintf(os.Stderr, "API 地址配置无效: %v\n", err)
+		os.Exit(1)
+	}
+	if err := app.ValidateNetwork(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "网络配置无效: %v\n", err)
+		os.Exit(1)
+	}
+
 	logger, cleanupLogger, err := logging.Setup(paths.LogFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "初始化日志失败: %v\n", err)
@@ -54,3 +79,20 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func runFakeServer(args []string) {
+	fs := flag.NewFlagSet("fake-server", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "监听地址")
+	latency := fs.Duration("latency", 0, "每个请求的额外延迟，例如 500ms")
+	fs.Parse(args)
+
+	handler := minimaxserver.NewHandler()
+	handler.SetBaseURL("http://" + *addr)
+	handler.SetLatency(*latency)
+
+	fmt.Fprintf(os.Stderr, "MiniMax 替身服务已启动：http://%s（使用 -base-url http://%s 连接，凭证可任意填写）\n", *addr, *addr)
+	if err := http.ListenAndServe(*addr, handler); err != nil {
+		fmt.Fprintf(os.Stderr, "替身服务退出: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -35,6 +38,12 @@ const (
 	stateCloning
 	stateSummary
 	stateExporting
+	statePrompt
+	stateFiles
+	stateVoices
+	stateTTS
+	stateDesign
+	stateBlend
 )
 
 var (
@@ -104,6 +113,9 @@ func (d fileDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	fmt.Fprintf(w, "%s%s %s", cursor, mark, file.Title())
 }
 
+// rateLimitCooldown 是重试耗尽后仍被限流时，该槽位处理下一个文件前的额外等待。测试中可调小。
+var rateLimitCooldown = 15 * time.Second
+
 type cloneStepMsg struct {
 	Path      string
 	VoiceID   string
@@ -115,8 +127,13 @@ type cloneStepMsg struct {
 }
 
 type cloneFinishedMsg struct {
-	Success int
-	Failed  int
+	Success   int
+	Failed    int
+	Skipped   int
+	Flagged   int
+	Cancelled int
+	// Aborted 表示批次由用户取消。
+	Aborted bool
 }
 
 type exportResultMsg struct {
@@ -132,12 +149,16 @@ type model struct {
 	rootPath string
 	homePath string
 	logger   zerolog.Logger
-	minimax  *minimax.Client
+	minimax  minimax.API
 
 	list          list.Model
 	delegate      fileDelegate
 	selected      map[string]bool
 	selectedOrder []string
+	prompts       map[string]promptPair
+	promptTarget  string
+	promptAudio   string
+	promptInput   textinput.Model
 
 	width      int
 	height     int
@@ -149,19 +170,73 @@ type model struct {
 	textInputs  []textinput.Model
 	activeInput int
 
-	confirmLines []string
+	confirmRows    []confirmRow
+	confirmCursor  int
+	cloneOpts      minimax.CloneOptions
+	previewInput   textinput.Model
+	previewEditing bool
+	demoDir        string
+	// voiceIDs 为开始克隆时各文件确定的 Voice ID；voiceIDPlanSeq 用于丢弃过期的预检结果。
+	voiceIDs        map[string]string
+	voiceIDPlanning bool
+	voiceIDPlanSeq  int
+	voiceIDTaken    map[string]bool
+	voiceIDNotes    []string
+	voiceIDInput    textinput.Model
+	voiceIDEditing  bool
 
 	spinner  spinner.Model
 	viewport viewport.Model
 	logs     []string
 
+	batchKind      batchKind
 	cloneQueue     []string
+	progressCh     chan tea.Msg
+	uploads        map[string]*uploadProgress
+	taskStatuses   map[string]taskStatusMsg
+	rateWait       rateWaitMsg
+	finishedFiles  map[string]bool
+	fileSizes      map[string]int64
+	batchBytes     int64
+	batchStarted   time.Time
 	cloneIndex     int
+	workers        []workerSlot
 	cloneSuccess   int
 	cloneFailed    int
+	cloneSkipped   int
+	cloneFlagged   int
+	cloneCancelled int
+	// batchCtx 随批次创建，Esc/S 取消时中止进行中的请求；cancelling 表示已请求取消。
+	batchCtx       context.Context
+	batchCancel    context.CancelFunc
+	cancelling     bool
 	pendingReload  bool
 	results        []exporter.Record
 	lastExportPath string
+	deleteUploaded bool
+	// designRecords 为本次会话的全部音色设计结果，每次设计后整体写入同一个 designExportPath。
+	designRecords    []exporter.Record
+	designExportPath string
+
+	remoteFiles        []minimax.File
+	filesCursor        int
+	filesLoading       bool
+	filesConfirmDelete bool
+
+	remoteVoices        []minimax.Voice
+	voicesCursor        int
+	voicesSelected      map[string]bool
+	voicesLoading       bool
+	voicesConfirmDelete bool
+
+	tts    ttsForm
+	design designForm
+	blend  blendForm
+	// longTTSReq 为长文本批次共用的合成参数，Text 在执行时按脚本文件填充。
+	longTTSReq minimax.SynthesizeRequest
+	longTTSDir string
+	// credentialsRejected 表示本批次因凭证无效而中止，返回时需引导用户重新填写。
+	credentialsRejected bool
 }
 
 func newModel(cfg config.Config, paths system.Paths, logger zerolog.Logger, rootPath string) *model {
@@ -190,17 +265,19 @@ func newModel(cfg config.Config, paths system.Paths, logger zerolog.Logger, root
 		delegate:      delegate,
 		selected:      make(map[string]bool),
 		selectedOrder: make([]string, 0),
+		prompts:       make(map[string]promptPair),
+		progressCh:    make(chan tea.Msg, 64),
+		uploads:       make(map[string]*uploadProgress),
+		finishedFiles: make(map[string]bool),
 		statusMsg:     "按 C 克隆 · Shift+C 编辑凭证 · 空格/X 勾选文件 · Enter 进入目录 · E 导出 · Q 退出",
 		spinner:       spin,
 		viewport:      viewport.Model{},
 	}
 	m.delegate.getSelected = m.isSelected
 	m.list.SetDelegate(m.delegate)
-	if cfg.IsComplete() {
-		m.minimax = minimax.NewClient(cfg.MinimaxSecret, cfg.MinimaxGroup)
+	m.state = stateConfig
+	if cfg.IsComplete() && m.connect() {
 		m.state = stateBrowser
-	} else {
-		m.state = stateConfig
 	}
 
 	m.initTextInputs()
@@ -225,7 +302,7 @@ func (m *model) initTextInputs() {
 }
 
 func (m *model) Init() tea.Cmd {
-	cmds := []tea.Cmd{}
+	cmds := []tea.Cmd{m.listenProgressCmd()}
 	if m.state == stateBrowser {
 		cmds = append(cmds, m.loadDirectoryCmd(m.rootPath))
 	}
@@ -251,6 +328,32 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyMsg(msg)
 	case cloneStepMsg:
 		return m.handleCloneStep(msg)
+	case workerStartMsg:
+		return m.handleWorkerStart(msg)
+	case uploadProgressMsg:
+		return m.handleUploadProgress(msg)
+	case taskStatusMsg:
+		return m.handleTaskStatus(msg)
+	case rateWaitMsg:
+		return m.handleRateWait(msg)
+	case filesLoadedMsg:
+		return m.handleFilesLoaded(msg)
+	case fileDeletedMsg:
+		return m.handleFileDeleted(msg)
+	case voicesLoadedMsg:
+		return m.handleVoicesLoaded(msg)
+	case voicesDeletedMsg:
+		return m.handleVoicesDeleted(msg)
+	case ttsFinishedMsg:
+		return m.handleTTSFinished(msg)
+	case designFinishedMsg:
+		return m.handleDesignFinished(msg)
+	case voiceIDPlanMsg:
+		return m.handleVoiceIDPlan(msg)
+	case historyLoadedMsg:
+		return m.handleHistoryLoaded(msg)
+	case blendFinishedMsg:
+		return m.handleBlendFinished(msg)
 	case cloneFinishedMsg:
 		return m.handleCloneFinished(msg)
 	case exportResultMsg:
@@ -271,7 +374,39 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
-	if m.state == stateCloning || m.state == stateExporting {
+	if m.state == statePrompt {
+		m.promptInput, cmd = m.promptInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.state == stateTTS && !m.tts.running {
+		if input, ok := m.tts.inputs[m.tts.focus]; ok {
+			*input, cmd = input.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.state == stateDesign && !m.design.running {
+		m.design.inputs[m.design.focus], cmd = m.design.inputs[m.design.focus].Update(msg)
+		return m, cmd
+	}
+
+	if m.state == stateBlend && m.blend.editingText {
+		m.blend.text, cmd = m.blend.text.Update(msg)
+		return m, cmd
+	}
+
+	if m.state == stateConfirm && m.previewEditing {
+		m.previewInput, cmd = m.previewInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.state == stateConfirm && m.voiceIDEditing {
+		m.voiceIDInput, cmd = m.voiceIDInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.state == stateCloning || m.state == stateExporting || (m.state == stateConfirm && m.voiceIDPlanning) || (m.state == stateFiles && m.filesLoading) || (m.state == stateVoices && m.voicesLoading) || (m.state == stateTTS && m.tts.running) || (m.state == stateDesign && m.design.running) || (m.state == stateBlend && (m.blend.loading || m.blend.running)) {
 		var spinCmd tea.Cmd
 		m.spinner, spinCmd = m.spinner.Update(msg)
 		return m, spinCmd
@@ -320,6 +455,18 @@ func (m *model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateSummaryKeys(msg)
 	case stateExporting:
 		return m, nil
+	case statePrompt:
+		return m.updatePromptKeys(msg)
+	case stateFiles:
+		return m.updateFilesKeys(msg)
+	case stateVoices:
+		return m.updateVoicesKeys(msg)
+	case stateTTS:
+		return m.updateTTSKeys(msg)
+	case stateDesign:
+		return m.updateDesignKeys(msg)
+	case stateBlend:
+		return m.updateBlendKeys(msg)
 	default:
 		return m, nil
 	}
@@ -379,15 +526,25 @@ func (m *model) saveConfig() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	newCfg := config.Config{MinimaxSecret: api, MinimaxGroup: group}
-	if err := config.Save(m.paths.ConfigFile, newCfg); err != nil {
+	// 仅回写凭证，避免把命令行或环境变量中的临时覆盖持久化到配置文件
+	fileCfg, err := config.Load(m.paths.ConfigFile)
+	if err != nil {
+		m.logger.Warn().Err(err).Msg("reload config before save failed")
+		fileCfg = config.Config{}
+	}
+	fileCfg.MinimaxSecret = api
+	fileCfg.MinimaxGroup = group
+	if err := config.Save(m.paths.ConfigFile, fileCfg); err != nil {
 		m.errorMsg = fmt.Sprintf("保存配置失败: %v", err)
 		m.logger.Error().Err(err).Msg("save config failed")
 		return m, nil
 	}
 
-	m.cfg = newCfg
-	m.minimax = minimax.NewClient(api, group)
+	m.cfg.MinimaxSecret = api
+	m.cfg.MinimaxGroup = group
+	if !m.connect() {
+		return m, nil
+	}
 	m.state = stateBrowser
 	m.statusMsg = "配置已更新，可继续操作。"
 	m.errorMsg = ""
@@ -406,16 +563,27 @@ func (m *model) updateBrowserKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMsg = "请先勾选至少一个文件"
 			return m, nil
 		}
+		if len(m.selectedScripts()) > 0 {
+			m.errorMsg = "已勾选 txt 脚本，克隆仅支持音频文件；长文本合成请按 A"
+			return m, nil
+		}
+		if len(m.cloneSamples()) == 0 {
+			m.errorMsg = "勾选的文件均为其他样本的提示音频，请勾选主样本"
+			return m, nil
+		}
 		m.state = stateConfirm
-		m.prepareConfirmLines()
-		return m, nil
+		m.errorMsg = ""
+		m.cloneOpts = cloneOptionsFromConfig(m.cfg.Clone)
+		m.deleteUploaded = m.cfg.Clone.DeleteUploadedFiles
+		m.initPreviewInput()
+		return m, m.openConfirmView()
 	case "C":
 		m.state = stateConfig
 		m.initTextInputs()
 		return m, nil
 	case "e":
 		if len(m.results) == 0 {
-			m.errorMsg = "暂无可导出的克隆记录"
+			m.errorMsg = "暂无可导出的克隆或设计记录"
 			return m, nil
 		}
 		m.state = stateExporting
@@ -431,6 +599,30 @@ func (m *model) updateBrowserKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.toggleSelection(item)
 		}
 		return m, nil
+	case "p":
+		if item, ok := m.list.SelectedItem().(fileItem); ok {
+			return m.beginPromptPairing(item)
+		}
+		return m, nil
+	case "P":
+		return m.clearPromptPairing()
+	case "f":
+		return m.openFilesView()
+	case "v":
+		return m.openVoicesView()
+	case "t":
+		return m.openTTSView(false)
+	case "a":
+		scripts := m.selectedScripts()
+		if len(scripts) == 0 || len(scripts) != len(m.selectedFiles()) {
+			m.errorMsg = "长文本合成请仅勾选 txt 脚本文件"
+			return m, nil
+		}
+		return m.openTTSView(true)
+	case "d":
+		return m.openDesignView()
+	case "b":
+		return m.openBlendView()
 	case "left", "h", "backspace":
 		return m.goParentDirectory()
 	case "right", "l":
@@ -475,38 +667,212 @@ func (m *model) currentDirOrRoot() string {
 	return m.currentDir
 }
 
+func (m *model) initPreviewInput() {
+	input := textinput.New()
+	input.Placeholder = "试听文本（留空则不生成试听音频）"
+	input.Prompt = ""
+	input.CharLimit = 300
+	input.SetValue(m.cloneOpts.Text)
+	m.previewInput = input
+	m.previewEditing = false
+}
+
+func (m *model) updatePreviewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.previewInput.SetValue(m.cloneOpts.Text)
+		m.previewInput.Blur()
+		m.previewEditing = false
+		return m, nil
+	case "enter":
+		m.cloneOpts.Text = strings.TrimSpace(m.previewInput.Value())
+		m.previewInput.Blur()
+		m.previewEditing = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.previewInput, cmd = m.previewInput.Update(msg)
+	return m, cmd
+}
+
 func (m *model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.previewEditing {
+		return m.updatePreviewInput(msg)
+	}
+	if m.voiceIDEditing {
+		return m.updateVoiceIDInput(msg)
+	}
 	switch msg.String() {
 	case "ctrl+c":
 		return m, tea.Quit
+	case "t":
+		m.previewEditing = true
+		return m, m.previewInput.Focus()
+	case "m":
+		m.cloneOpts.Model = nextModel(m.cloneOpts.Model)
+		return m, nil
+	case "up", "k":
+		if m.confirmCursor > 0 {
+			m.confirmCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.confirmCursor < len(m.confirmRows)-1 {
+			m.confirmCursor++
+		}
+		return m, nil
+	case "e":
+		if m.voiceIDPlanning {
+			return m, nil
+		}
+		return m, m.startVoiceIDEdit()
+	case "r":
+		if len(m.confirmRows) > 0 && !m.voiceIDPlanning {
+			row := &m.confirmRows[m.confirmCursor]
+			row.voiceID = row.proposed
+			m.validateConfirmRows()
+		}
+		return m, nil
 	case "esc", "n":
 		m.state = stateBrowser
+		m.errorMsg = ""
+		m.voiceIDPlanning = false
+		return m, nil
+	case "1":
+		m.cloneOpts.NeedNoiseReduction = !m.cloneOpts.NeedNoiseReduction
+		return m, nil
+	case "2":
+		m.cloneOpts.NeedVolumeNormalization = !m.cloneOpts.NeedVolumeNormalization
+		return m, nil
+	case "3":
+		m.deleteUploaded = !m.deleteUploaded
+		return m, nil
+	case "+", "=":
+		m.cloneOpts.Accuracy = adjustAccuracy(m.cloneOpts.Accuracy, accuracyStep)
+		return m, nil
+	case "-":
+		m.cloneOpts.Accuracy = adjustAccuracy(m.cloneOpts.Accuracy, -accuracyStep)
 		return m, nil
 	case "enter", "y":
-		m.state = stateCloning
-		m.cloneQueue = m.selectedFiles()
-		m.cloneIndex = 0
-		m.cloneSuccess = 0
-		m.cloneFailed = 0
-		m.logs = nil
-		m.results = nil
-		m.lastExportPath = ""
-		m.viewport = viewport.New(m.width-4, m.height-6)
-		m.viewport.SetContent("")
-		m.statusMsg = "正在执行克隆任务..."
-		return m, tea.Batch(m.spinner.Tick, m.nextCloneCmd())
+		return m.startCloneBatch()
 	}
 	return m, nil
 }
 
+// startBatch 重置计数与日志并进入执行界面，克隆与长文本合成共用同一队列与进度展示。
+func (m *model) startBatch(kind batchKind, queue []string, intro ...string) tea.Cmd {
+	m.state = stateCloning
+	m.batchKind = kind
+	m.cloneQueue = queue
+	m.resetBatchProgress(m.cloneQueue)
+	m.cloneIndex = 0
+	m.workers = make([]workerSlot, max(1, min(concurrencyFromConfig(m.cfg.Clone), len(queue))))
+	m.cloneSuccess = 0
+	m.cloneFailed = 0
+	m.cloneSkipped = 0
+	m.cloneFlagged = 0
+	m.cloneCancelled = 0
+	m.cancelling = false
+	m.batchCtx, m.batchCancel = context.WithCancel(context.Background())
+	m.credentialsRejected = false
+	m.logs = nil
+	m.results = nil
+	m.lastExportPath = ""
+	m.viewport = viewport.New(m.width-4, m.height-6)
+	m.statusMsg = fmt.Sprintf("正在执行%s任务...", kind.label())
+	if len(m.workers) > 1 {
+		intro = append(intro, fmt.Sprintf("并发处理：同时处理 %d 个文件", len(m.workers)))
+	}
+	for _, line := range intro {
+		m.logs = append(m.logs, fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), line))
+	}
+	m.viewport.SetContent(strings.Join(m.logs, "\n"))
+	return tea.Batch(m.spinner.Tick, m.nextCloneCmd())
+}
+
+// accuracyStep 是确认界面每次调整文本校验准确率阈值的步长。
+const accuracyStep = 0.05
+
+func adjustAccuracy(current, delta float64) float64 {
+	next := math.Round((current+delta)*100) / 100
+	return math.Min(math.Max(next, 0), 1)
+}
+
+func cloneOptionsFromConfig(c config.Clone) minimax.CloneOptions {
+	model := c.Model
+	if model == "" {
+		model = minimax.DefaultModel
+	}
+	return minimax.CloneOptions{
+		NeedNoiseReduction:      c.NeedNoiseReduction,
+		NeedVolumeNormalization: c.NeedVolumeNormalization,
+		Accuracy:                adjustAccuracy(c.Accuracy, 0),
+		Text:                    strings.TrimSpace(c.PreviewText),
+		Model:                   model,
+	}
+}
+
+func nextModel(current string) string {
+	for i, model := range minimax.Models {
+		if model == current {
+			return minimax.Models[(i+1)%len(minimax.Models)]
+		}
+	}
+	return minimax.Models[0]
+}
+
+func describeCloneOptions(opts minimax.CloneOptions) string {
+	desc := fmt.Sprintf("降噪 %s · 音量归一化 %s · 准确率阈值 %s",
+		onOff(opts.NeedNoiseReduction), onOff(opts.NeedVolumeNormalization), formatAccuracy(opts.Accuracy))
+	if opts.Text != "" {
+		desc += fmt.Sprintf(" · 试听模型 %s", opts.Model)
+	}
+	return desc
+}
+
+func formatAccuracy(v float64) string {
+	if v <= 0 {
+		return "默认"
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func onOff(v bool) string {
+	if v {
+		return "开"
+	}
+	return "关"
+}
+
 func (m *model) updateCloningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
 		return m, tea.Quit
+	case "esc", "s":
+		m.cancelBatch()
 	}
 	return m, nil
 }
 
+// cancelBatch 中止进行中的请求并将尚未开始的文件记为已取消。进行中的文件返回后
+// 由 handleCloneStep 记录，随后照常导出 CSV 并进入汇总界面。
+func (m *model) cancelBatch() {
+	if m.cancelling || m.batchCancel == nil {
+		return
+	}
+	m.cancelling = true
+	m.batchCancel()
+	ts := time.Now().Format("15:04:05")
+	m.logger.Info().Int("remaining", len(m.cloneQueue)-m.cloneIndex).Msg("batch cancelled by user")
+	m.logs = append(m.logs, fmt.Sprintf("[%s] ⏹ 已请求取消，正在中止进行中的请求...", ts))
+	m.abandonRemaining(exporter.StatusCancelled, "批次已取消，未处理", minimax.ErrorCode(context.Canceled))
+	m.statusMsg = fmt.Sprintf("正在取消%s任务...", m.batchKind.label())
+	m.viewport.SetContent(strings.Join(m.logs, "\n"))
+	m.viewport.GotoBottom()
+}
+
 func (m *model) updateSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
@@ -523,37 +889,40 @@ func (m *model) updateSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statusMsg = "按 C 克隆 · Shift+C 编辑凭证 · 空格/X 勾选文件 · Enter 进入目录 · E 导出 · Q 退出"
 		m.cloneQueue = nil
 		m.cloneIndex = 0
+		m.workers = nil
 		m.cloneSuccess = 0
 		m.cloneFailed = 0
+		m.cloneSkipped = 0
+		m.cloneFlagged = 0
+		m.cloneCancelled = 0
+		m.cancelling = false
 		m.errorMsg = ""
+		if m.credentialsRejected {
+			m.credentialsRejected = false
+			m.state = stateConfig
+			m.activeInput = 0
+			m.initTextInputs()
+			m.errorMsg = "MiniMax 凭证无效或缺失，请重新填写"
+		}
 		return m, cmd
 	}
 	return m, nil
 }
 
-func (m *model) prepareConfirmLines() {
-	paths := m.selectedFiles()
-	lines := make([]string, len(paths))
-	sort.Strings(paths)
-	for i, p := range paths {
-		lines[i] = p
-	}
-	m.confirmLines = lines
-}
-
 func (m *model) toggleSelection(item fileItem) {
 	if item.isDir {
 		return
 	}
 	ext := strings.ToLower(filepath.Ext(item.path))
 	switch ext {
-	case ".mp3", ".m4a", ".wav":
+	case ".mp3", ".m4a", ".wav", ".txt":
 	default:
-		m.errorMsg = "仅支持选择 mp3、m4a、wav 文件"
+		m.errorMsg = "仅支持选择 mp3、m4a、wav 音频或 txt 脚本文件"
 		return
 	}
 	if m.selected[item.path] {
 		delete(m.selected, item.path)
+		delete(m.prompts, item.path)
 		for i, p := range m.selectedOrder {
 			if p == item.path {
 				m.selectedOrder = append(m.selectedOrder[:i], m.selectedOrder[i+1:]...)
@@ -585,36 +954,118 @@ func (m *model) handleCloneStep(msg cloneStepMsg) (tea.Model, tea.Cmd) {
 	for _, line := range msg.Logs {
 		m.logs = append(m.logs, fmt.Sprintf("[%s] %s", ts, line))
 	}
+	// 取消（含凭证失效后的中止）导致的失败记为已取消，取消前已完成的结果保持不变。
+	if (m.cancelling || m.credentialsRejected) && msg.Record != nil && errors.Is(msg.Err, context.Canceled) {
+		msg.Record.Status = exporter.StatusCancelled
+		m.logs = append(m.logs, fmt.Sprintf("[%s] ⏹ 已取消：%s", ts, filepath.Base(msg.Path)))
+	}
 	if msg.Record != nil {
 		m.results = append(m.results, *msg.Record)
 	}
-	if msg.Err != nil {
+	m.markFileFinished(msg.Path)
+	slot := m.releaseWorker(msg.Path)
+	switch {
+	case msg.Record != nil && msg.Record.Status == exporter.StatusCancelled:
+		m.cloneCancelled++
+	case msg.Record != nil && msg.Record.Status == exporter.StatusSkipped:
+		m.cloneSkipped++
+	case msg.Record != nil && msg.Record.Status == exporter.StatusFlagged:
+		m.cloneFlagged++
+	case msg.Err != nil:
 		m.cloneFailed++
-	} else {
+	default:
 		m.cloneSuccess++
 	}
+
+	if errors.Is(msg.Err, minimax.ErrInvalidCredentials) || errors.Is(msg.Err, minimax.ErrMissingCredentials) {
+		m.credentialsRejected = true
+		m.skipRemaining(ts, "凭证无效，未处理", minimax.ErrorCode(msg.Err))
+	}
+
+	// 触发服务端限流时只推迟本槽位的下一个文件：先为其预留文件，冷却结束后再开始处理，其余槽位照常分派。
+	var cmds []tea.Cmd
+	if errors.Is(msg.Err, minimax.ErrRateLimited) && slot >= 0 && m.cloneIndex < len(m.cloneQueue) {
+		m.logs = append(m.logs, fmt.Sprintf("[%s] ⏸ 触发 MiniMax 限流，工作线程 %d %s 后继续", ts, slot+1, rateLimitCooldown))
+		cmds = append(cmds, m.reserveWorker(slot, rateLimitCooldown))
+	}
+	cmds = append(cmds, m.nextCloneCmd())
+	cmd := tea.Batch(cmds...)
 	m.viewport.SetContent(strings.Join(m.logs, "\n"))
 	m.viewport.GotoBottom()
-	cmd := m.nextCloneCmd()
 	return m, cmd
 }
 
+// skipRemaining 用于不可恢复的错误：中止其他槽位进行中的请求，并将队列中尚未处理的文件标记为跳过。
+func (m *model) skipRemaining(ts, reason, code string) {
+	if m.batchCancel != nil {
+		m.batchCancel()
+	}
+	for _, path := range m.abandonRemaining(exporter.StatusSkipped, reason, code) {
+		m.logs = append(m.logs, fmt.Sprintf("[%s] ⏭ 跳过文件：%s（%s）", ts, filepath.Base(path), reason))
+	}
+}
+
+// abandonRemaining 将队列中尚未开始的文件按 status（已取消或跳过）记入结果并计数，返回这些文件。
+func (m *model) abandonRemaining(status, reason, code string) []string {
+	paths := m.cloneQueue[m.cloneIndex:]
+	for _, path := range paths {
+		m.results = append(m.results, exporter.Record{
+			FilePath:       path,
+			MinimaxVoiceID: m.voiceIDs[path],
+			Status:         status,
+			ErrorReason:    reason,
+			ErrorCode:      code,
+			UpdatedAt:      time.Now(),
+		})
+		m.markFileFinished(path)
+		if status == exporter.StatusCancelled {
+			m.cloneCancelled++
+		} else {
+			m.cloneSkipped++
+		}
+	}
+	m.cloneIndex = len(m.cloneQueue)
+	return paths
+}
+
+// delayCmd 在 d 之后执行 cmd；ctx 提前结束时立即执行，由 cmd 自行按已取消处理。
+func delayCmd(ctx context.Context, d time.Duration, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		return cmd()
+	}
+}
+
 func (m *model) handleCloneFinished(msg cloneFinishedMsg) (tea.Model, tea.Cmd) {
+	if m.batchCancel != nil {
+		m.batchCancel()
+	}
+	outcome := "完成"
+	if msg.Aborted {
+		outcome = "已取消"
+	}
+	m.sortResults()
 	csvPath, exportErr := exporter.ToCSV(m.results, m.paths.DownloadsDir)
 	if exportErr != nil {
 		timestamp := time.Now().Format("15:04:05")
 		m.logs = append(m.logs, fmt.Sprintf("[%s] ❌ 自动导出失败：%v", timestamp, exportErr))
-		m.statusMsg = fmt.Sprintf("克隆完成：成功 %d · 失败 %d · 导出失败（按 q 返回）", msg.Success, msg.Failed)
+		m.statusMsg = fmt.Sprintf("%s%s：%s · 导出失败（按 q 返回）", m.batchKind.label(), outcome, describeCounts(msg.Success, msg.Failed, msg.Skipped, msg.Flagged, msg.Cancelled))
 		m.lastExportPath = ""
 	} else {
 		timestamp := time.Now().Format("15:04:05")
 		m.logs = append(m.logs, fmt.Sprintf("[%s] ✅ 结果已导出：%s", timestamp, csvPath))
-		m.statusMsg = fmt.Sprintf("克隆完成：成功 %d · 失败 %d · CSV：%s (按 q 返回)", msg.Success, msg.Failed, csvPath)
+		m.statusMsg = fmt.Sprintf("%s%s：%s · CSV：%s (按 q 返回)", m.batchKind.label(), outcome, describeCounts(msg.Success, msg.Failed, msg.Skipped, msg.Flagged, msg.Cancelled), csvPath)
 		m.lastExportPath = csvPath
 	}
 	m.state = stateSummary
 	m.selected = make(map[string]bool)
 	m.selectedOrder = nil
+	m.prompts = make(map[string]promptPair)
 	m.pendingReload = true
 	m.errorMsg = ""
 	m.viewport.SetContent(strings.Join(m.logs, "\n"))
@@ -635,87 +1086,332 @@ func (m *model) handleExportResult(msg exportResultMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// nextCloneCmd 为每个空闲槽位分派队列中的下一个文件；队列已空且没有进行中的文件时结束批次。
 func (m *model) nextCloneCmd() tea.Cmd {
-	if m.cloneIndex >= len(m.cloneQueue) {
-		success := m.cloneSuccess
-		failed := m.cloneFailed
+	var cmds []tea.Cmd
+	for i := range m.workers {
+		if m.workers[i].path != "" || m.cloneIndex >= len(m.cloneQueue) {
+			continue
+		}
+		path := m.cloneQueue[m.cloneIndex]
+		m.cloneIndex++
+		m.workers[i] = workerSlot{path: path, started: time.Now()}
+		cmds = append(cmds, m.fileCmd(path))
+	}
+	if len(cmds) > 0 {
+		return tea.Batch(cmds...)
+	}
+	if m.busyWorkers() == 0 {
+		msg := cloneFinishedMsg{
+			Success:   m.cloneSuccess,
+			Failed:    m.cloneFailed,
+			Skipped:   m.cloneSkipped,
+			Flagged:   m.cloneFlagged,
+			Cancelled: m.cloneCancelled,
+			Aborted:   m.cancelling,
+		}
 		return func() tea.Msg {
-			return cloneFinishedMsg{Success: success, Failed: failed}
+			return msg
+		}
+	}
+	return nil
+}
+
+// fileCmd 构造处理单个文件的命令，克隆与长文本合成按批次类型区分。
+func (m *model) fileCmd(path string) tea.Cmd {
+	if m.batchKind == batchLongTTS {
+		return longTTSCmd(m.minimax, m.longTTSJob(path), m.logger)
+	}
+	job := cloneJob{
+		ctx:            m.batchCtx,
+		path:           path,
+		opts:           m.cloneOpts,
+		voiceID:        m.voiceIDs[path],
+		demoDir:        m.demoDir,
+		deleteUploaded: m.deleteUploaded,
+		progress:       m.progressReporter(path),
+	}
+	if pair, ok := m.promptFor(path); ok {
+		job.prompt = &pair
+	}
+	return cloneFileCmd(m.minimax, job, m.logger)
+}
+
+// connect 按当前配置重建客户端。API 地址无效时不创建客户端并停留在凭证界面提示错误，
+// 避免请求连同 API Key 被发往默认地址。
+func (m *model) connect() bool {
+	client, err := m.newClient()
+	if err != nil {
+		m.logger.Error().Err(err).Msg("resolve base url failed")
+		m.errorMsg = fmt.Sprintf("API 地址配置无效：%v（请检查 region 与 base_url）", err)
+		m.state = stateConfig
+		return false
+	}
+	m.minimax = client
+	return true
+}
+
+// newClient 按当前配置创建客户端，并接入限速提示与可选的请求追踪。
+// 网络配置在启动时已由 ValidateNetwork 校验，此处失败（例如证书文件被移走）时退回默认连接设置。
+func (m *model) newClient() (*minimax.Client, error) {
+	opts := []minimax.Option{minimax.WithRateLimitObserver(m.rateLimitObserver())}
+	netOpts, err := networkOptions(m.cfg.Network)
+	if err != nil {
+		m.logger.Error().Err(err).Msg("apply network config failed")
+		m.errorMsg = fmt.Sprintf("网络配置无效，已使用默认连接设置：%v", err)
+	}
+	opts = append(opts, netOpts...)
+	if m.cfg.Trace {
+		opts = append(opts, minimax.WithTrace(m.logger))
+	}
+	return newMinimaxClient(m.cfg, opts...)
+}
+
+// ValidateNetwork 检查代理、证书与超时配置能否生效，供启动时提前报错。
+func ValidateNetwork(cfg config.Config) error {
+	_, err := networkOptions(cfg.Network)
+	return err
+}
+
+// networkOptions 将 [network] 配置转换为客户端选项：代理与证书作用于传输层，超时按接口类别设置。
+func networkOptions(n config.Network) ([]minimax.Option, error) {
+	timeouts := minimax.Timeouts{}
+	for class, value := range map[minimax.EndpointClass]string{
+		minimax.ClassUpload:   n.Timeouts.Upload,
+		minimax.ClassClone:    n.Timeouts.Clone,
+		minimax.ClassTTS:      n.Timeouts.TTS,
+		minimax.ClassQuery:    n.Timeouts.Query,
+		minimax.ClassDownload: n.Timeouts.Download,
+	} {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s timeout: %w", class, err)
+		}
+		timeouts[class] = d
+	}
+	opts := []minimax.Option{minimax.WithTimeouts(timeouts)}
+
+	if n.ProxyURL == "" && len(n.CAFiles) == 0 && n.ClientCertFile == "" && n.ClientKeyFile == "" {
+		return opts, nil
+	}
+	transport, err := minimax.NewTransport(minimax.TransportOptions{
+		ProxyURL:       n.ProxyURL,
+		CAFiles:        n.CAFiles,
+		ClientCertFile: n.ClientCertFile,
+		ClientKeyFile:  n.ClientKeyFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, minimax.WithTransport(transport)), nil
+}
+
+func newMinimaxClient(cfg config.Config, opts ...minimax.Option) (*minimax.Client, error) {
+	baseURL, err := minimax.ResolveBaseURL(cfg.BaseURL, cfg.Region)
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]minimax.Option{
+		minimax.WithBaseURL(baseURL),
+		minimax.WithRateLimits(rateLimitsFromConfig(cfg.RateLimit)),
+	}, opts...)
+	return minimax.NewClient(cfg.MinimaxSecret, cfg.MinimaxGroup, opts...), nil
+}
+
+// rateLimitsFromConfig 以内置默认值为基础，应用配置文件中非零的 RPM 设置。
+func rateLimitsFromConfig(c config.RateLimit) minimax.RateLimits {
+	limits := minimax.DefaultRateLimits()
+	for class, rpm := range map[minimax.EndpointClass]int{
+		minimax.ClassUpload: c.UploadRPM,
+		minimax.ClassClone:  c.CloneRPM,
+		minimax.ClassTTS:    c.TTSRPM,
+		minimax.ClassQuery:  c.QueryRPM,
+	} {
+		if rpm != 0 {
+			limits[class] = rpm
 		}
 	}
-	path := m.cloneQueue[m.cloneIndex]
-	m.cloneIndex++
-	return cloneFileCmd(m.minimax, path, m.logger)
+	return limits
 }
 
-func cloneFileCmd(client *minimax.Client, path string, logger zerolog.Logger) tea.Cmd {
+// cloneJob 描述队列中单个文件的克隆任务。
+type cloneJob struct {
+	ctx            context.Context
+	path           string
+	opts           minimax.CloneOptions
+	voiceID        string
+	demoDir        string
+	prompt         *promptPair
+	deleteUploaded bool
+	progress       minimax.ProgressFunc
+}
+
+func cloneFileCmd(client minimax.VoiceCloner, job cloneJob, logger zerolog.Logger) tea.Cmd {
+	path, opts := job.path, job.opts
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := job.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
 		timestamp := time.Now()
 		logs := []string{
 			fmt.Sprintf("开始处理文件：%s", filepath.Base(path)),
 			"  → 正在上传文件...",
 		}
 
-		voiceID, err := minimax.GenerateVoiceID(path)
-		if err != nil {
-			logger.Error().Err(err).Str("file", path).Msg("generate voice id failed")
-			logs = append(logs, fmt.Sprintf("  ❌ 生成 Voice ID 失败：%v", err))
-			rec := exporter.Record{
-				FilePath:    path,
-				Status:      "failed",
-				ErrorReason: err.Error(),
-				UpdatedAt:   time.Now(),
+		// Voice ID 通常已在确认界面确定，仅在未指定时按默认规则生成。
+		voiceID := job.voiceID
+		if voiceID == "" {
+			generated, err := minimax.GenerateVoiceID(path)
+			if err != nil {
+				logger.Error().Err(err).Str("file", path).Msg("generate voice id failed")
+				logs = append(logs, fmt.Sprintf("  ❌ 生成 Voice ID 失败：%v", err))
+				rec := exporter.Record{
+					FilePath:    path,
+					Status:      exporter.StatusFailed,
+					ErrorReason: err.Error(),
+					ErrorCode:   minimax.ErrorCode(err),
+					UpdatedAt:   time.Now(),
+				}
+				return cloneStepMsg{Path: path, Err: err, Timestamp: timestamp, Logs: logs, Record: &rec}
 			}
-			return cloneStepMsg{Path: path, Err: err, Timestamp: timestamp, Logs: logs, Record: &rec}
+			voiceID = generated
 		}
-		logs = append(logs, fmt.Sprintf("  → 生成 Voice ID：%s", voiceID))
+		logs = append(logs, fmt.Sprintf("  → 使用 Voice ID：%s", voiceID))
 
-		uploadResp, err := client.UploadFile(ctx, path)
+		uploadResp, err := client.UploadFile(minimax.WithProgress(ctx, job.progress), path, minimax.PurposeVoiceClone)
 		if err != nil {
-			logger.Error().Err(err).Str("file", path).Msg("upload failed")
+			logger.Error().Err(err).Str("file", path).Int("attempts", minimax.Attempts(err)).Msg("upload failed")
 			rec := exporter.Record{
-				FilePath:    path,
-				Status:      "failed",
-				ErrorReason: err.Error(),
-				UpdatedAt:   time.Now(),
+				FilePath:       path,
+				MinimaxVoiceID: voiceID,
+				Status:         exporter.StatusFailed,
+				ErrorReason:    err.Error(),
+				ErrorCode:      minimax.ErrorCode(err),
+				UpdatedAt:      time.Now(),
+				UploadAttempts: minimax.Attempts(err),
 			}
-			logs = append(logs, fmt.Sprintf("  ❌ 上传失败：%v", err))
+			logs = append(logs, fmt.Sprintf("  ❌ 上传失败：%v%s", err, errorHint(err)))
 			return cloneStepMsg{Path: path, Err: err, Timestamp: timestamp, Logs: logs, Record: &rec}
 		}
 
 		fileID := uploadResp.File.FileID
 		fileIDStr := strconv.FormatInt(fileID, 10)
-		logs = append(logs, fmt.Sprintf("  ✅ 上传成功，文件ID：%s", fileIDStr))
+		logs = append(logs, fmt.Sprintf("  ✅ 上传成功，文件ID：%s%s", fileIDStr, attemptsNote(uploadResp.Attempts)))
+
+		var promptPath, promptFileIDStr string
+		if job.prompt != nil {
+			promptPath = job.prompt.AudioPath
+			logs = append(logs, fmt.Sprintf("  → 正在上传提示音频：%s", filepath.Base(promptPath)))
+			promptResp, err := client.UploadFile(ctx, promptPath, minimax.PurposePromptAudio)
+			if err != nil {
+				logger.Error().Err(err).Str("file", path).Str("prompt", promptPath).Msg("upload prompt audio failed")
+				rec := exporter.Record{
+					FilePath:       path,
+					MinimaxFileID:  fileIDStr,
+					MinimaxVoiceID: voiceID,
+					Status:         exporter.StatusFailed,
+					ErrorReason:    err.Error(),
+					ErrorCode:      minimax.ErrorCode(err),
+					UpdatedAt:      time.Now(),
+					UploadAttempts: uploadResp.Attempts,
+					PromptFilePath: promptPath,
+				}
+				logs = append(logs, fmt.Sprintf("  ❌ 提示音频上传失败：%v%s", err, errorHint(err)))
+				return cloneStepMsg{Path: path, Err: err, Timestamp: timestamp, Logs: logs, Record: &rec}
+			}
+			promptFileIDStr = strconv.FormatInt(promptResp.File.FileID, 10)
+			opts.ClonePrompt = &minimax.ClonePrompt{
+				PromptAudio: promptResp.File.FileID,
+				PromptText:  job.prompt.Text,
+			}
+			logs = append(logs, fmt.Sprintf("  ✅ 提示音频上传成功，文件ID：%s%s", promptFileIDStr, attemptsNote(promptResp.Attempts)))
+		}
 		logs = append(logs, fmt.Sprintf("  → 正在克隆音色（Voice ID：%s）...", voiceID))
 
-		cloneResp, err := client.CloneWithFileID(ctx, fileID, voiceID)
+		cloneResp, err := client.CloneWithFileID(ctx, fileID, voiceID, opts)
 		if err != nil {
-			logger.Error().Err(err).Str("file", path).Msg("clone failed")
+			logger.Error().Err(err).Str("file", path).Int("attempts", minimax.Attempts(err)).Msg("clone failed")
 			rec := exporter.Record{
 				FilePath:       path,
 				MinimaxFileID:  fileIDStr,
 				MinimaxVoiceID: voiceID,
-				Status:         "failed",
+				Status:         exporter.StatusFailed,
 				ErrorReason:    err.Error(),
+				ErrorCode:      minimax.ErrorCode(err),
 				UpdatedAt:      time.Now(),
+				UploadAttempts: uploadResp.Attempts,
+				CloneAttempts:  minimax.Attempts(err),
+				PromptFilePath: promptPath,
+				PromptFileID:   promptFileIDStr,
 			}
-			logs = append(logs, fmt.Sprintf("  ❌ 克隆失败：%v", err))
+			if errors.Is(err, minimax.ErrDuplicateVoiceID) {
+				rec.Status = exporter.StatusSkipped
+				logs = append(logs, fmt.Sprintf("  ⏭ Voice ID 已存在，跳过：%v", err))
+				return cloneStepMsg{Path: path, Err: err, Timestamp: time.Now(), Logs: logs, Record: &rec}
+			}
+			logs = append(logs, fmt.Sprintf("  ❌ 克隆失败：%v%s", err, errorHint(err)))
 			return cloneStepMsg{Path: path, Err: err, Timestamp: time.Now(), Logs: logs, Record: &rec}
 		}
 
-		logs = append(logs,
-			fmt.Sprintf("  ✅ 克隆成功，Voice ID：%s", voiceID),
-			fmt.Sprintf("     MiniMax 状态：%s", cloneResp.BaseResp.StatusMsg),
-		)
-
 		rec := exporter.Record{
 			FilePath:       path,
 			MinimaxFileID:  fileIDStr,
 			MinimaxVoiceID: voiceID,
-			Status:         "success",
+			Status:         exporter.StatusSuccess,
 			ErrorReason:    "",
 			UpdatedAt:      time.Now(),
+			UploadAttempts: uploadResp.Attempts,
+			CloneAttempts:  cloneResp.Attempts,
+			DemoAudioURL:   cloneResp.DemoAudio,
+			PromptFilePath: promptPath,
+			PromptFileID:   promptFileIDStr,
+		}
+
+		if cloneResp.InputSensitive {
+			reason := minimax.SensitiveTypeText(cloneResp.InputSensitiveType)
+			rec.Status = exporter.StatusFlagged
+			rec.ErrorReason = "输入内容被标记为敏感：" + reason
+			rec.InputSensitiveType = cloneResp.InputSensitiveType
+			logger.Warn().Str("file", path).Str("voice_id", voiceID).Int("input_sensitive_type", cloneResp.InputSensitiveType).Msg("clone input flagged as sensitive")
+			logs = append(logs, fmt.Sprintf("  🚩 克隆完成但输入内容被标记为敏感（类型 %d：%s），请合规复核，Voice ID：%s", cloneResp.InputSensitiveType, reason, voiceID))
+		} else {
+			logs = append(logs, fmt.Sprintf("  ✅ 克隆成功，Voice ID：%s%s", voiceID, attemptsNote(cloneResp.Attempts)))
+		}
+		logs = append(logs, fmt.Sprintf("     MiniMax 状态：%s", cloneResp.BaseResp.StatusMsg))
+
+		if job.deleteUploaded {
+			rec.UploadDeleted = true
+			if err := client.DeleteFile(ctx, fileID, minimax.PurposeVoiceClone); err != nil {
+				rec.UploadDeleted = false
+				logger.Warn().Err(err).Str("file", path).Int64("file_id", fileID).Msg("delete uploaded file failed")
+				logs = append(logs, fmt.Sprintf("  ⚠️ 删除已上传文件失败：%v", err))
+			}
+			if opts.ClonePrompt != nil {
+				if err := client.DeleteFile(ctx, opts.ClonePrompt.PromptAudio, minimax.PurposePromptAudio); err != nil {
+					rec.UploadDeleted = false
+					logger.Warn().Err(err).Str("file", path).Int64("file_id", opts.ClonePrompt.PromptAudio).Msg("delete prompt audio failed")
+					logs = append(logs, fmt.Sprintf("  ⚠️ 删除提示音频失败：%v", err))
+				}
+			}
+			if rec.UploadDeleted {
+				logs = append(logs, "  🗑 已删除 MiniMax 上的源文件")
+			}
+		}
+
+		if cloneResp.DemoAudio != "" && job.demoDir != "" {
+			demoPath := filepath.Join(job.demoDir, voiceID+demoExt(cloneResp.DemoAudio))
+			if _, err := client.Download(ctx, cloneResp.DemoAudio, demoPath); err != nil {
+				logger.Warn().Err(err).Str("file", path).Str("voice_id", voiceID).Msg("download demo audio failed")
+				logs = append(logs, fmt.Sprintf("  ⚠️ 试听音频下载失败：%v", err))
+			} else {
+				rec.DemoAudioPath = demoPath
+				logs = append(logs, fmt.Sprintf("  🎧 试听音频：%s", demoPath))
+			}
 		}
 
 		logger.Info().Str("file", path).Str("voice_id", voiceID).Msg("clone success")
@@ -730,6 +1426,56 @@ func cloneFileCmd(client *minimax.Client, path string, logger zerolog.Logger) te
 	}
 }
 
+// describeCounts 汇总批次结果，敏感标记与取消数仅在非零时列出。
+func describeCounts(success, failed, skipped, flagged, cancelled int) string {
+	text := fmt.Sprintf("成功 %d · 失败 %d · 跳过 %d", success, failed, skipped)
+	if flagged > 0 {
+		text += fmt.Sprintf(" · 敏感标记 %d", flagged)
+	}
+	if cancelled > 0 {
+		text += fmt.Sprintf(" · 已取消 %d", cancelled)
+	}
+	return text
+}
+
+// demoExt 从试听音频地址中推断扩展名，无法识别时按 mp3 处理。
+func demoExt(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		switch ext := strings.ToLower(path.Ext(u.Path)); ext {
+		case ".mp3", ".wav", ".flac", ".m4a", ".pcm":
+			return ext
+		}
+	}
+	return ".mp3"
+}
+
+// errorHint 针对可识别的 MiniMax 错误给出处理建议。
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, minimax.ErrInvalidCredentials), errors.Is(err, minimax.ErrMissingCredentials):
+		return "（凭证无效，将中止剩余文件，返回后请重新填写凭证）"
+	case errors.Is(err, minimax.ErrRateLimited):
+		return "（触发限流，稍后自动继续）"
+	case errors.Is(err, minimax.ErrInsufficientBalance):
+		return "（账户余额不足）"
+	case errors.Is(err, minimax.ErrInvalidAudio):
+		return "（音频不符合要求，请检查时长与质量）"
+	case errors.Is(err, minimax.ErrSensitiveContent):
+		return "（内容触发安全审核）"
+	case errors.Is(err, minimax.ErrPermissionDenied):
+		return "（账户无此操作权限）"
+	}
+	return ""
+}
+
+// attemptsNote 在发生重试时返回附加到日志行的尝试次数说明。
+func attemptsNote(attempts int) string {
+	if attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("（共尝试 %d 次）", attempts)
+}
+
 func (m *model) exportCmd() tea.Cmd {
 	records := make([]exporter.Record, len(m.results))
 	copy(records, m.results)
@@ -845,6 +1591,18 @@ func (m *model) View() string {
 		return m.viewSummary()
 	case stateExporting:
 		return m.viewExporting()
+	case statePrompt:
+		return m.viewPrompt()
+	case stateFiles:
+		return m.viewFiles()
+	case stateVoices:
+		return m.viewVoices()
+	case stateTTS:
+		return m.viewTTS()
+	case stateDesign:
+		return m.viewDesign()
+	case stateBlend:
+		return m.viewBlend()
 	default:
 		return ""
 	}
@@ -866,6 +1624,9 @@ func (m *model) viewConfig() string {
 		}
 	}
 
+	if baseURL, err := minimax.ResolveBaseURL(m.cfg.BaseURL, m.cfg.Region); err == nil {
+		fmt.Fprintf(&b, "%s\n", helpStyle.Render("API 地址："+baseURL))
+	}
 	fmt.Fprintf(&b, "%s\n", helpStyle.Render("Tab 切换输入框 · Enter 保存 · Esc 取消 · Ctrl+C 退出"))
 	if m.errorMsg != "" {
 		fmt.Fprintf(&b, "\n%s\n", errorStyle.Render(m.errorMsg))
@@ -882,7 +1643,7 @@ func (m *model) viewBrowser() string {
 	right := borderStyle.Width(m.width - m.listWidth() - 4).Render(m.viewSelectedPanel())
 
 	header := titleStyle.Render(fmt.Sprintf("当前目录：%s", m.displayPath(m.currentDirOrRoot())))
-	help := helpStyle.Render("空格/X 勾选/取消 · C 克隆 · Shift+C 编辑凭证 · Enter 进入目录 · 方向键/hjkl 导航 · E 导出 · Q 退出")
+	help := helpStyle.Render("空格/X 勾选/取消 · P 设为提示音频 · C 克隆 · F 远程文件 · V 音色库 · T 试听合成 · A 长文本合成 · D 音色设计 · B 混合音色 · Shift+C 编辑凭证 · Enter 进入目录 · 方向键/hjkl 导航 · E 导出 · Q 退出")
 	requirements := helpStyle.Render("音频要求：格式 mp3/m4a/wav · 时长 10 秒至 5 分钟 · 大小不超过 20 MB")
 
 	status := m.statusMsg
@@ -908,24 +1669,64 @@ func (m *model) viewBrowser() string {
 func (m *model) viewConfirm() string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "%s\n\n", confirmStyle.Render("确认克隆以下文件？"))
-	for _, line := range m.confirmLines {
-		fmt.Fprintf(&b, "• %s\n", line)
+	b.WriteString(m.viewConfirmTable())
+	fmt.Fprintf(&b, "\n%s\n", titleStyle.Render("本批次克隆参数"))
+	fmt.Fprintf(&b, "[1] 降噪：%s\n", onOff(m.cloneOpts.NeedNoiseReduction))
+	fmt.Fprintf(&b, "[2] 音量归一化：%s\n", onOff(m.cloneOpts.NeedVolumeNormalization))
+	fmt.Fprintf(&b, "[3] 克隆成功后删除已上传文件：%s\n", onOff(m.deleteUploaded))
+	fmt.Fprintf(&b, "[+/-] 准确率阈值：%s\n", formatAccuracy(m.cloneOpts.Accuracy))
+	if m.previewEditing {
+		fmt.Fprintf(&b, "[T] 试听文本：%s\n", selectedStyle.Render(m.previewInput.View()))
+	} else if m.cloneOpts.Text != "" {
+		fmt.Fprintf(&b, "[T] 试听文本：%s\n", m.cloneOpts.Text)
+	} else {
+		fmt.Fprintf(&b, "[T] 试听文本：%s\n", helpStyle.Render("未设置（不生成试听音频）"))
 	}
-	fmt.Fprintf(&b, "\n%s", helpStyle.Render("按 Enter/Y 开始克隆 · 按 Esc/N 取消"))
-	return borderStyle.Width(m.width - 4).Render(b.String())
+	fmt.Fprintf(&b, "[M] 试听模型：%s\n", m.cloneOpts.Model)
+	fmt.Fprintf(&b, "Voice ID 规则：%s\n", describeVoiceIDConfig(m.cfg.VoiceID))
+	fmt.Fprintf(&b, "并发数：%d\n", concurrencyFromConfig(m.cfg.Clone))
+
+	help := "↑/↓ 选择文件 · E 编辑 Voice ID · R 恢复建议值 · 按 1/2/3 切换选项 · +/- 调整阈值 · T 编辑试听文本 · M 切换模型 · Enter/Y 开始克隆 · Esc/N 取消"
+	if m.previewEditing {
+		help = "Enter 确认试听文本 · Esc 放弃修改"
+	}
+	if m.voiceIDEditing {
+		help = "Enter 确认 Voice ID · Esc 放弃修改"
+	}
+	if m.voiceIDPlanning {
+		fmt.Fprintf(&b, "\n%s 正在生成并检查 Voice ID...\n", m.spinner.View())
+	}
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render(help))
+	view := borderStyle.Width(m.width - 4).Render(b.String())
+	if m.errorMsg != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, errorStyle.Render(m.errorMsg))
+	}
+	return view
 }
 
 func (m *model) viewCloning() string {
-	header := titleStyle.Render("正在执行克隆任务...")
+	header := titleStyle.Render(fmt.Sprintf("正在执行%s任务...", m.batchKind.label()))
+	help := helpStyle.Render("Esc/S 取消批次（已完成的结果仍会导出）· Ctrl+C 退出")
+	if m.cancelling {
+		header = titleStyle.Render(fmt.Sprintf("正在取消%s任务...", m.batchKind.label()))
+		help = helpStyle.Render("等待进行中的请求中止，随后导出 CSV")
+	}
 	spin := m.spinner.View()
+	progress := m.viewUploadProgress()
+	if workers := m.viewWorkers(); workers != "" {
+		progress = lipgloss.JoinVertical(lipgloss.Left, workers, progress)
+	}
+	if wait := m.viewRateWait(); wait != "" {
+		progress = lipgloss.JoinVertical(lipgloss.Left, wait, progress)
+	}
 	content := m.viewport.View()
-	summary := statusStyle.Render(fmt.Sprintf("已完成：成功 %d · 失败 %d · 共 %d", m.cloneSuccess, m.cloneFailed, len(m.cloneQueue)))
-	return lipgloss.JoinVertical(lipgloss.Left, header, spin, content, summary)
+	summary := statusStyle.Render(fmt.Sprintf("已完成：%s · 共 %d", describeCounts(m.cloneSuccess, m.cloneFailed, m.cloneSkipped, m.cloneFlagged, m.cloneCancelled), len(m.cloneQueue)))
+	return lipgloss.JoinVertical(lipgloss.Left, header, spin, progress, content, summary, help)
 }
 
 func (m *model) viewSummary() string {
-	header := titleStyle.Render("克隆结果日志")
-	summary := statusStyle.Render(fmt.Sprintf("成功 %d · 失败 %d · 按 q 返回", m.cloneSuccess, m.cloneFailed))
+	header := titleStyle.Render(fmt.Sprintf("%s结果日志", m.batchKind.label()))
+	summary := statusStyle.Render(fmt.Sprintf("%s · 按 q 返回", describeCounts(m.cloneSuccess, m.cloneFailed, m.cloneSkipped, m.cloneFlagged, m.cloneCancelled)))
 	content := m.viewport.View()
 	help := helpStyle.Render("按 q 返回文件选择，Ctrl+C 退出")
 	return lipgloss.JoinVertical(lipgloss.Left, header, summary, content, help)
@@ -945,6 +1746,9 @@ func (m *model) viewSelectedPanel() string {
 	for _, path := range m.selectedOrder {
 		if m.selected[path] {
 			fmt.Fprintf(&b, "%s\n", path)
+			if pair, ok := m.prompts[path]; ok {
+				fmt.Fprintf(&b, "  ↳ 提示：%s\n", filepath.Base(pair.AudioPath))
+			}
 		}
 	}
 	return b.String()
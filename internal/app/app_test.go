@@ -0,0 +1,230 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rs/zerolog"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+	"minimax/internal/minimax/minimaxfake"
+	"minimax/internal/system"
+)
+
+// newTestModel 返回以 minimaxfake 代替真实客户端的模型，导出目录为临时目录。
+func newTestModel(t *testing.T, clone config.Clone) (*model, *minimaxfake.Fake, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.Config{MinimaxSecret: "test-key", MinimaxGroup: "test-group", Clone: clone}
+	paths := system.Paths{DownloadsDir: filepath.Join(dir, "downloads"), DemosDir: filepath.Join(dir, "demos")}
+	m := newModel(cfg, paths, zerolog.Nop(), dir)
+	m.width, m.height = 120, 40
+	fake := minimaxfake.New()
+	m.minimax = fake
+	return m, fake, dir
+}
+
+// writeSamples 在 dir 中写入 n 个内容互不相同的样本音频。
+func writeSamples(t *testing.T, dir string, n int) []string {
+	t.Helper()
+	paths := make([]string, n)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("sample%02d.mp3", i+1))
+		if err := os.WriteFile(paths[i], []byte(fmt.Sprintf("sample audio %d", i+1)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+u":
+		return tea.KeyMsg{Type: tea.KeyCtrlU}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// drive 模拟 Bubble Tea 的事件循环：每个命令在独立的 goroutine 中执行，返回的消息按到达顺序逐条交给
+// Update，直到 done 对某条已处理的消息返回 true。并发槽位同时完成时 cloneStepMsg 的到达顺序因此不固定。
+// 旋转动画的计时消息直接丢弃，否则循环永远不会空闲。
+func drive(t *testing.T, m *model, cmd tea.Cmd, done func(tea.Msg) bool) {
+	t.Helper()
+	msgs := make(chan tea.Msg, 64)
+	pending := 0
+	run := func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		pending++
+		go func() { msgs <- cmd() }()
+	}
+	run(cmd)
+
+	timeout := time.After(10 * time.Second)
+	for pending > 0 {
+		var msg tea.Msg
+		select {
+		case msg = <-msgs:
+			pending--
+		case <-timeout:
+			t.Fatalf("event loop did not finish (state %d, %d commands pending)", m.state, pending)
+		}
+		switch msg := msg.(type) {
+		case nil, spinner.TickMsg:
+			continue
+		case tea.BatchMsg:
+			for _, c := range msg {
+				run(c)
+			}
+			continue
+		}
+		_, next := m.Update(msg)
+		if done(msg) {
+			return
+		}
+		run(next)
+	}
+	t.Fatalf("event loop went idle before finishing (state %d)", m.state)
+}
+
+// openConfirm 在文件浏览中选中 paths 并按 c 进入确认界面，等待 Voice ID 预检完成。
+func openConfirm(t *testing.T, m *model, paths []string) {
+	t.Helper()
+	m.state = stateBrowser
+	for _, path := range paths {
+		m.selected[path] = true
+		m.selectedOrder = append(m.selectedOrder, path)
+	}
+	_, cmd := m.Update(keyMsg("c"))
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		_, ok := msg.(voiceIDPlanMsg)
+		return ok
+	})
+	if m.state != stateConfirm || m.voiceIDPlanning {
+		t.Fatalf("confirm view not ready: state %d, planning %v, error %q", m.state, m.voiceIDPlanning, m.errorMsg)
+	}
+}
+
+// runClone 经文件浏览、确认界面与克隆批次的完整流程处理 paths，返回批次结束消息。
+func runClone(t *testing.T, m *model, paths []string) cloneFinishedMsg {
+	t.Helper()
+	openConfirm(t, m, paths)
+
+	var finished cloneFinishedMsg
+	_, cmd := m.Update(keyMsg("enter"))
+	if m.state != stateCloning {
+		t.Fatalf("batch did not start: %q", m.errorMsg)
+	}
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		f, ok := msg.(cloneFinishedMsg)
+		finished = f
+		return ok
+	})
+	return finished
+}
+
+// exportedRecords 读取批次结束时自动导出的 CSV。
+func exportedRecords(t *testing.T, m *model) []exporter.Record {
+	t.Helper()
+	if m.lastExportPath == "" {
+		t.Fatalf("batch was not exported: %s", m.statusMsg)
+	}
+	records, err := exporter.LoadHistory(filepath.Dir(m.lastExportPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestCloneAttemptsAreExported(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		n := calls[r.URL.Path]
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/files/upload":
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"file":{"file_id":42},"base_resp":{"status_code":0,"status_msg":"success"}}`)
+		case "/v1/voice_clone":
+			if n < 3 {
+				fmt.Fprint(w, `{"base_resp":{"status_code":1002,"status_msg":"rate limit"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"base_resp":{"status_code":0,"status_msg":"success"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	sample := filepath.Join(dir, "sample.mp3")
+	if err := os.WriteFile(sample, []byte("audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := minimax.NewClient("key", "group",
+		minimax.WithBaseURL(srv.URL),
+		minimax.WithRateLimits(minimax.RateLimits{}),
+		minimax.WithRetryPolicy(minimax.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+
+	msg := cloneFileCmd(client, cloneJob{ctx: context.Background(), path: sample, voiceID: "voice-sample-01"}, zerolog.Nop())().(cloneStepMsg)
+	if msg.Err != nil {
+		t.Fatalf("clone failed: %v", msg.Err)
+	}
+	if _, err := exporter.ToCSV([]exporter.Record{*msg.Record}, dir); err != nil {
+		t.Fatal(err)
+	}
+	history, err := exporter.LoadHistory(dir)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("LoadHistory = %v, %v", history, err)
+	}
+	if got := history[0]; got.UploadAttempts != 2 || got.CloneAttempts != 3 {
+		t.Errorf("exported attempts: upload %d, clone %d; want 2, 3", got.UploadAttempts, got.CloneAttempts)
+	}
+}
+
+func TestInvalidBaseURLStaysOnConfig(t *testing.T) {
+	for _, cfg := range []config.Config{
+		{MinimaxSecret: "key", MinimaxGroup: "group", Region: "cn-north"},
+		{MinimaxSecret: "key", MinimaxGroup: "group", BaseURL: "api.minimax.io"},
+	} {
+		dir := t.TempDir()
+		m := newModel(cfg, system.Paths{DownloadsDir: dir, ConfigFile: filepath.Join(dir, "config.toml")}, zerolog.Nop(), dir)
+		// 地址无效时不应退回默认地址，否则 API Key 会被发往未预期的主机。
+		if m.state != stateConfig || m.minimax != nil || !strings.Contains(m.errorMsg, "API 地址配置无效") {
+			t.Errorf("%+v: state %d, client %v, error %q", cfg, m.state, m.minimax, m.errorMsg)
+		}
+
+		m.textInputs[0].SetValue("key")
+		m.textInputs[1].SetValue("group")
+		m.saveConfig()
+		if m.state != stateConfig || m.minimax != nil {
+			t.Errorf("%+v: saving credentials left the config screen with an invalid base url", cfg)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+const (
+	defaultBlendWeight = 50
+	blendWeightStep    = 5
+)
+
+// blendForm 为混合音色界面的状态：从克隆历史中勾选至多 minimax.MaxTimbreWeights 个音色并设置权重。
+type blendForm struct {
+	voices      []string
+	cursor      int
+	weights     map[string]int
+	order       []string
+	text        textinput.Model
+	editingText bool
+	loading     bool
+	running     bool
+}
+
+type historyLoadedMsg struct {
+	Records []exporter.Record
+	Err     error
+}
+
+type blendFinishedMsg struct {
+	Path         string
+	MetadataPath string
+	Result       *minimax.SynthesizeResult
+	Err          error
+}
+
+// blendMetadata 与输出音频同名保存为 JSON，记录混合配方以便复现。
+type blendMetadata struct {
+	Voices      []minimax.TimbreWeight `json:"voices"`
+	Text        string                 `json:"text"`
+	Model       string                 `json:"model"`
+	Format      string                 `json:"format"`
+	AudioLength int64                  `json:"audio_length_ms"`
+	TraceID     string                 `json:"trace_id,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+func (m *model) openBlendView() (tea.Model, tea.Cmd) {
+	text := textinput.New()
+	text.Placeholder = "要合成的文本"
+	text.Prompt = ""
+	text.CharLimit = 0
+
+	m.blend = blendForm{
+		weights: make(map[string]int),
+		text:    text,
+		loading: true,
+	}
+	m.state = stateBlend
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, tea.Batch(m.spinner.Tick, loadHistoryCmd(m.paths.DownloadsDir))
+}
+
+func loadHistoryCmd(downloadsDir string) tea.Cmd {
+	return func() tea.Msg {
+		records, err := exporter.LoadHistory(downloadsDir)
+		return historyLoadedMsg{Records: records, Err: err}
+	}
+}
+
+// historyVoiceIDs 合并本次会话与历史导出中成功创建的音色，本次会话的结果排在前面。
+// 长文本合成记录中的 Voice ID 只是所用音色，不计入。
+func historyVoiceIDs(current, history []exporter.Record) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(rec exporter.Record) {
+		if rec.Status != exporter.StatusSuccess || rec.MinimaxVoiceID == "" || rec.TaskID != "" || seen[rec.MinimaxVoiceID] {
+			return
+		}
+		seen[rec.MinimaxVoiceID] = true
+		ids = append(ids, rec.MinimaxVoiceID)
+	}
+	for i := len(current) - 1; i >= 0; i-- {
+		add(current[i])
+	}
+	for _, rec := range history {
+		add(rec)
+	}
+	return ids
+}
+
+func (m *model) handleHistoryLoaded(msg historyLoadedMsg) (tea.Model, tea.Cmd) {
+	m.blend.loading = false
+	if msg.Err != nil {
+		m.logger.Error().Err(msg.Err).Msg("load clone history failed")
+		m.errorMsg = fmt.Sprintf("读取克隆历史失败：%v", msg.Err)
+	}
+	m.blend.voices = historyVoiceIDs(m.results, msg.Records)
+	return m, nil
+}
+
+func (m *model) toggleBlendVoice(id string) {
+	if _, ok := m.blend.weights[id]; ok {
+		delete(m.blend.weights, id)
+		for i, v := range m.blend.order {
+			if v == id {
+				m.blend.order = append(m.blend.order[:i], m.blend.order[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+	if len(m.blend.order) >= minimax.MaxTimbreWeights {
+		m.errorMsg = fmt.Sprintf("最多混合 %d 个音色", minimax.MaxTimbreWeights)
+		return
+	}
+	m.blend.weights[id] = defaultBlendWeight
+	m.blend.order = append(m.blend.order, id)
+}
+
+func (m *model) blendWeights() []minimax.TimbreWeight {
+	weights := make([]minimax.TimbreWeight, 0, len(m.blend.order))
+	for _, id := range m.blend.order {
+		weights = append(weights, minimax.TimbreWeight{VoiceID: id, Weight: m.blend.weights[id]})
+	}
+	return weights
+}
+
+func (m *model) updateBlendKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.blend.running || m.blend.loading {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	if m.blend.editingText {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "tab", "esc":
+			m.blend.editingText = false
+			m.blend.text.Blur()
+			return m, nil
+		case "enter":
+			return m.startBlend()
+		}
+		var cmd tea.Cmd
+		m.blend.text, cmd = m.blend.text.Update(msg)
+		return m, cmd
+	}
+
+	m.errorMsg = ""
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.state = stateBrowser
+		m.infoMsg = ""
+		return m, m.loadDirectoryCmd(m.currentDirOrRoot())
+	case "tab", "t":
+		m.blend.editingText = true
+		return m, m.blend.text.Focus()
+	case "up", "k":
+		if m.blend.cursor > 0 {
+			m.blend.cursor--
+		}
+	case "down", "j":
+		if m.blend.cursor < len(m.blend.voices)-1 {
+			m.blend.cursor++
+		}
+	case " ", "x":
+		if m.blend.cursor < len(m.blend.voices) {
+			m.toggleBlendVoice(m.blend.voices[m.blend.cursor])
+		}
+	case "+", "=", "right", "l", "-", "left", "h":
+		if m.blend.cursor >= len(m.blend.voices) {
+			return m, nil
+		}
+		id := m.blend.voices[m.blend.cursor]
+		weight, ok := m.blend.weights[id]
+		if !ok {
+			return m, nil
+		}
+		switch msg.String() {
+		case "-", "left", "h":
+			weight -= blendWeightStep
+		default:
+			weight += blendWeightStep
+		}
+		m.blend.weights[id] = max(1, min(weight, 100))
+	case "enter":
+		return m.startBlend()
+	}
+	return m, nil
+}
+
+func (m *model) startBlend() (tea.Model, tea.Cmd) {
+	req := minimax.SynthesizeRequest{
+		Model:         minimax.DefaultModel,
+		Text:          strings.TrimSpace(m.blend.text.Value()),
+		AudioSetting:  minimax.AudioSetting{Format: minimax.DefaultAudioFormat},
+		TimbreWeights: m.blendWeights(),
+	}
+	if len(req.TimbreWeights) < 2 {
+		m.errorMsg = "请至少勾选两个音色进行混合"
+		return m, nil
+	}
+	if err := req.Validate(); err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	m.blend.running = true
+	m.blend.editingText = false
+	m.blend.text.Blur()
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, tea.Batch(m.spinner.Tick, blendCmd(m.minimax, req, m.paths.TTSDir))
+}
+
+func blendCmd(client minimax.Synthesizer, req minimax.SynthesizeRequest, dir string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.Synthesize(context.Background(), req)
+		if err != nil {
+			return blendFinishedMsg{Err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return blendFinishedMsg{Err: fmt.Errorf("ensure tts directory: %w", err)}
+		}
+		path := ttsOutputPath(dir, "blend", result.Format)
+		if err := os.WriteFile(path, result.Audio, 0o644); err != nil {
+			return blendFinishedMsg{Err: fmt.Errorf("write audio file: %w", err)}
+		}
+
+		meta := blendMetadata{
+			Voices:      req.TimbreWeights,
+			Text:        req.Text,
+			Model:       req.Model,
+			Format:      result.Format,
+			AudioLength: result.AudioLength,
+			TraceID:     result.TraceID,
+			CreatedAt:   time.Now(),
+		}
+		data, err := json.MarshalIndent(meta, "", "  ")
+		if err != nil {
+			return blendFinishedMsg{Path: path, Result: result, Err: fmt.Errorf("marshal blend metadata: %w", err)}
+		}
+		metaPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+		if err := os.WriteFile(metaPath, data, 0o644); err != nil {
+			return blendFinishedMsg{Path: path, Result: result, Err: fmt.Errorf("write blend metadata: %w", err)}
+		}
+		return blendFinishedMsg{Path: path, MetadataPath: metaPath, Result: result}
+	}
+}
+
+func (m *model) handleBlendFinished(msg blendFinishedMsg) (tea.Model, tea.Cmd) {
+	m.blend.running = false
+	if msg.Path == "" {
+		m.logger.Error().Err(msg.Err).Msg("blend synthesize failed")
+		m.errorMsg = fmt.Sprintf("混合合成失败：%v%s", msg.Err, errorHint(msg.Err))
+		return m, nil
+	}
+	m.logger.Info().Str("path", msg.Path).Str("trace_id", msg.Result.TraceID).Msg("blend synthesize success")
+	m.infoMsg = fmt.Sprintf("已保存：%s", msg.Path)
+	m.errorMsg = ""
+	if msg.Err != nil {
+		m.logger.Warn().Err(msg.Err).Str("path", msg.Path).Msg("write blend metadata failed")
+		m.errorMsg = fmt.Sprintf("音频已保存，但混合配方写入失败：%v", msg.Err)
+	}
+	return m, nil
+}
+
+func (m *model) viewBlend() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render("混合音色合成"))
+
+	switch {
+	case m.blend.loading:
+		fmt.Fprintf(&b, "%s 正在读取克隆历史...\n", m.spinner.View())
+	case len(m.blend.voices) == 0:
+		fmt.Fprintf(&b, "%s\n", helpStyle.Render("暂无克隆历史，请先完成克隆或音色设计"))
+	default:
+		total := 0
+		for _, w := range m.blend.weights {
+			total += w
+		}
+		for i, id := range m.blend.voices {
+			cursor := "  "
+			if i == m.blend.cursor && !m.blend.editingText {
+				cursor = "> "
+			}
+			weight, ok := m.blend.weights[id]
+			if !ok {
+				fmt.Fprintf(&b, "%s[ ] %s\n", cursor, id)
+				continue
+			}
+			fmt.Fprintf(&b, "%s[x] %-40s 权重 %3d（%.0f%%）\n", cursor, id, weight, float64(weight)/float64(total)*100)
+		}
+	}
+
+	label := "  合成文本："
+	if m.blend.editingText {
+		label = selectedStyle.Render("> 合成文本：")
+	}
+	fmt.Fprintf(&b, "\n%s%s\n", label, m.blend.text.View())
+
+	if m.blend.running {
+		fmt.Fprintf(&b, "\n%s 正在合成...\n", m.spinner.View())
+	}
+
+	help := fmt.Sprintf("空格/X 勾选（最多 %d 个）· +/- 调整权重 · Tab/T 编辑文本 · Enter 合成并保存 · Esc/Q 返回", minimax.MaxTimbreWeights)
+	if m.blend.editingText {
+		help = "Enter 合成并保存 · Tab/Esc 返回音色列表"
+	}
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render(help))
+
+	status := ""
+	if m.infoMsg != "" {
+		status = statusStyle.Render(m.infoMsg)
+	}
+	if m.errorMsg != "" {
+		status = errorStyle.Render(m.errorMsg)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, borderStyle.Width(m.width-4).Render(b.String()), status)
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+func TestHistoryVoiceIDs(t *testing.T) {
+	current := []exporter.Record{
+		{MinimaxVoiceID: "voice-session-1", Status: exporter.StatusSuccess},
+		{MinimaxVoiceID: "voice-failed", Status: exporter.StatusFailed},
+		{MinimaxVoiceID: "voice-session-2", Status: exporter.StatusSuccess},
+	}
+	history := []exporter.Record{
+		{MinimaxVoiceID: "voice-session-1", Status: exporter.StatusSuccess},
+		{MinimaxVoiceID: "voice-old", Status: exporter.StatusSuccess},
+		// 长文本合成记录中的 Voice ID 只是所用音色。
+		{MinimaxVoiceID: "voice-used-by-tts", Status: exporter.StatusSuccess, TaskID: "9001"},
+		{MinimaxVoiceID: "voice-skipped", Status: exporter.StatusSkipped},
+		{Status: exporter.StatusSuccess},
+	}
+	want := []string{"voice-session-2", "voice-session-1", "voice-old"}
+	if got := historyVoiceIDs(current, history); !reflect.DeepEqual(got, want) {
+		t.Errorf("historyVoiceIDs = %v, want %v", got, want)
+	}
+}
+
+func TestBlendWritesMetadata(t *testing.T) {
+	m, _, dir := newTestModel(t, config.Clone{})
+	m.paths.TTSDir = filepath.Join(dir, "tts")
+	var history []exporter.Record
+	for _, id := range []string{"voice-a-0001", "voice-b-0002", "voice-c-0003"} {
+		history = append(history, exporter.Record{MinimaxVoiceID: id, Status: exporter.StatusSuccess})
+	}
+	if _, err := exporter.ToCSV(history, m.paths.DownloadsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	m.state = stateBrowser
+	_, cmd := m.Update(keyMsg("b"))
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		_, ok := msg.(historyLoadedMsg)
+		return ok
+	})
+	if want := []string{"voice-a-0001", "voice-b-0002", "voice-c-0003"}; !reflect.DeepEqual(m.blend.voices, want) {
+		t.Fatalf("blend voices = %v, want %v", m.blend.voices, want)
+	}
+
+	// 先选 c 再选 a，配方按勾选顺序记录；c 提高到 60，a 降到 45。
+	press(m, "j", "j", "x", "+", "+", "k", "k", "x", "-")
+	press(m, "t", "你好，世界")
+	_, cmd = m.Update(keyMsg("enter"))
+	var finished blendFinishedMsg
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		f, ok := msg.(blendFinishedMsg)
+		finished = f
+		return ok
+	})
+	if finished.Err != nil || finished.MetadataPath == "" {
+		t.Fatalf("blend finished = %+v", finished)
+	}
+
+	data, err := os.ReadFile(finished.MetadataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta blendMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("metadata %s: %v", data, err)
+	}
+	wantVoices := []minimax.TimbreWeight{{VoiceID: "voice-c-0003", Weight: 60}, {VoiceID: "voice-a-0001", Weight: 45}}
+	if !reflect.DeepEqual(meta.Voices, wantVoices) {
+		t.Errorf("metadata voices = %+v, want %+v", meta.Voices, wantVoices)
+	}
+	if meta.Text != "你好，世界" || meta.Model != minimax.DefaultModel || meta.Format != minimax.DefaultAudioFormat || meta.AudioLength != 1000 || meta.CreatedAt.IsZero() {
+		t.Errorf("metadata = %+v", meta)
+	}
+	if want := finished.Path[:len(finished.Path)-len(filepath.Ext(finished.Path))] + ".json"; finished.MetadataPath != want {
+		t.Errorf("metadata path %s, want next to %s", finished.MetadataPath, finished.Path)
+	}
+
+	// 导出的 JSON 使用约定的字段名，外部脚本按此复现配方。
+	var raw map[string]any
+	json.Unmarshal(data, &raw)
+	for _, key := range []string{"voices", "text", "model", "format", "audio_length_ms", "created_at"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("metadata is missing %q: %s", key, data)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+	"minimax/internal/minimax/minimaxfake"
+)
+
+func TestCloneBatchWithFake(t *testing.T) {
+	tests := []struct {
+		name  string
+		files int
+		setup func(f *minimaxfake.Fake)
+		// want 为各文件在 CSV 中的状态与错误码，按队列顺序排列。
+		want      []string
+		wantCount cloneFinishedMsg
+		rejected  bool
+	}{
+		{
+			name:      "all succeed",
+			files:     3,
+			setup:     func(f *minimaxfake.Fake) { f.SetDelay(minimaxfake.OpUpload, 5*time.Millisecond) },
+			want:      []string{"success/", "success/", "success/"},
+			wantCount: cloneFinishedMsg{Success: 3},
+		},
+		{
+			name:  "upload failure",
+			files: 3,
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpUpload, nil, &minimax.APIError{Op: "upload", HTTPStatus: 502})
+			},
+			want:      []string{"success/", "failed/server_error", "success/"},
+			wantCount: cloneFinishedMsg{Success: 2, Failed: 1},
+		},
+		{
+			name:  "clone failures",
+			files: 3,
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpClone,
+					minimaxfake.StatusError("clone", 1008, "insufficient balance"),
+					nil,
+					minimaxfake.StatusError("clone", 2037, "invalid audio"))
+			},
+			want:      []string{"failed/insufficient_balance", "success/", "failed/invalid_audio"},
+			wantCount: cloneFinishedMsg{Success: 1, Failed: 2},
+		},
+		{
+			name:  "duplicate voice id is skipped",
+			files: 2,
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpClone, minimaxfake.StatusError("clone", 2039, "voice id already exists"))
+			},
+			want:      []string{"skipped/duplicate_voice_id", "success/"},
+			wantCount: cloneFinishedMsg{Success: 1, Skipped: 1},
+		},
+		{
+			name:      "sensitive input is flagged",
+			files:     3,
+			setup:     func(f *minimaxfake.Fake) { f.FlagNext(0, 3) },
+			want:      []string{"success/", "flagged/", "success/"},
+			wantCount: cloneFinishedMsg{Success: 2, Flagged: 1},
+		},
+		{
+			name:  "rejected credentials skip the rest",
+			files: 3,
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpUpload, minimaxfake.StatusError("upload", 1004, "invalid api key"))
+			},
+			want:      []string{"failed/invalid_credentials", "skipped/invalid_credentials", "skipped/invalid_credentials"},
+			wantCount: cloneFinishedMsg{Failed: 1, Skipped: 2},
+			rejected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, fake, dir := newTestModel(t, config.Clone{})
+			tt.setup(fake)
+			paths := writeSamples(t, dir, tt.files)
+
+			finished := runClone(t, m, paths)
+			if finished != tt.wantCount {
+				t.Errorf("finished = %+v, want %+v", finished, tt.wantCount)
+			}
+			if got := (cloneFinishedMsg{Success: m.cloneSuccess, Failed: m.cloneFailed, Skipped: m.cloneSkipped, Flagged: m.cloneFlagged, Cancelled: m.cloneCancelled}); got != tt.wantCount {
+				t.Errorf("model counters = %+v, want %+v", got, tt.wantCount)
+			}
+			if m.state != stateSummary || m.credentialsRejected != tt.rejected {
+				t.Errorf("state %d, credentialsRejected %v", m.state, m.credentialsRejected)
+			}
+			if !strings.Contains(m.statusMsg, describeCounts(tt.wantCount.Success, tt.wantCount.Failed, tt.wantCount.Skipped, tt.wantCount.Flagged, 0)) {
+				t.Errorf("status %q does not summarise the counts", m.statusMsg)
+			}
+
+			records := exportedRecords(t, m)
+			if len(records) != len(paths) {
+				t.Fatalf("exported %d records, want %d", len(records), len(paths))
+			}
+			for i, rec := range records {
+				if rec.FilePath != paths[i] {
+					t.Errorf("row %d is %s, want %s", i, filepath.Base(rec.FilePath), filepath.Base(paths[i]))
+				}
+				if got := rec.Status + "/" + rec.ErrorCode; got != tt.want[i] {
+					t.Errorf("row %d status/code = %s, want %s", i, got, tt.want[i])
+				}
+				if rec.MinimaxVoiceID != m.voiceIDs[paths[i]] {
+					t.Errorf("row %d voice id %q, want %q", i, rec.MinimaxVoiceID, m.voiceIDs[paths[i]])
+				}
+			}
+		})
+	}
+}
+
+func TestCloneBatchCredentialRejectionAbortsWorkers(t *testing.T) {
+	m, fake, dir := newTestModel(t, config.Clone{Concurrency: 3})
+	// 首个上传因凭证无效失败，另外两个槽位停在克隆请求上，只有中止批次才能结束。
+	fake.FailNext(minimaxfake.OpUpload, minimaxfake.StatusError("upload", 1004, "invalid api key"))
+	fake.SetDelay(minimaxfake.OpClone, time.Hour)
+	paths := writeSamples(t, dir, 4)
+
+	finished := runClone(t, m, paths)
+	if want := (cloneFinishedMsg{Failed: 1, Skipped: 1, Cancelled: 2}); finished != want {
+		t.Errorf("finished = %+v, want %+v", finished, want)
+	}
+	if !m.credentialsRejected || m.busyWorkers() != 0 {
+		t.Errorf("credentialsRejected %v, busy workers %d", m.credentialsRejected, m.busyWorkers())
+	}
+
+	statuses := map[string]int{}
+	for i, rec := range exportedRecords(t, m) {
+		statuses[rec.Status+"/"+rec.ErrorCode]++
+		if rec.FilePath != paths[i] || rec.MinimaxVoiceID != m.voiceIDs[paths[i]] {
+			t.Errorf("row %d = %s (%q), want %s (%q)", i, filepath.Base(rec.FilePath), rec.MinimaxVoiceID, filepath.Base(paths[i]), m.voiceIDs[paths[i]])
+		}
+		if !m.finishedFiles[rec.FilePath] {
+			t.Errorf("row %d not counted towards batch progress", i)
+		}
+	}
+	want := map[string]int{"failed/invalid_credentials": 1, "cancelled/cancelled": 2, "skipped/invalid_credentials": 1}
+	for k, n := range want {
+		if statuses[k] != n {
+			t.Errorf("statuses = %v, want %v", statuses, want)
+			break
+		}
+	}
+}
+
+func TestCloneBatchFlaggedRecord(t *testing.T) {
+	m, fake, dir := newTestModel(t, config.Clone{})
+	fake.FlagNext(2)
+	paths := writeSamples(t, dir, 1)
+
+	runClone(t, m, paths)
+	rec := exportedRecords(t, m)[0]
+	if rec.Status != exporter.StatusFlagged || rec.InputSensitiveType != 2 || !strings.Contains(rec.ErrorReason, minimax.SensitiveTypeText(2)) {
+		t.Errorf("flagged record = %+v", rec)
+	}
+}
+
+func TestCloneBatchSkipsPromptAudio(t *testing.T) {
+	m, fake, dir := newTestModel(t, config.Clone{})
+	paths := writeSamples(t, dir, 3)
+	// sample01 手动配对 sample02 作为提示音频，sample03 使用约定命名的提示文件。
+	m.prompts[paths[0]] = promptPair{AudioPath: paths[1], Text: "手动配对"}
+	sidecar := filepath.Join(dir, "sample03.prompt.wav")
+	if err := os.WriteFile(sidecar, []byte("prompt audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sample03.prompt.txt"), []byte("约定命名"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	finished := runClone(t, m, append(paths, sidecar))
+	if finished.Success != 2 {
+		t.Fatalf("finished = %+v, want 2 clones", finished)
+	}
+	records := exportedRecords(t, m)
+	if len(records) != 2 || records[0].FilePath != paths[0] || records[0].PromptFilePath != paths[1] ||
+		records[1].FilePath != paths[2] || records[1].PromptFilePath != sidecar {
+		t.Errorf("records = %+v", records)
+	}
+	clones := 0
+	for _, call := range fake.Calls() {
+		if call.Op == minimaxfake.OpClone {
+			clones++
+		}
+	}
+	if clones != 2 {
+		t.Errorf("cloned %d voices, want 2", clones)
+	}
+}
+
+func TestCloneBatchDeletesUploadsAndDownloadsDemo(t *testing.T) {
+	m, fake, dir := newTestModel(t, config.Clone{DeleteUploadedFiles: true, PreviewText: "你好"})
+	paths := writeSamples(t, dir, 2)
+
+	runClone(t, m, paths)
+	for _, rec := range exportedRecords(t, m) {
+		if rec.Status != exporter.StatusSuccess || !rec.UploadDeleted || rec.DemoAudioPath == "" {
+			t.Errorf("record = %+v", rec)
+		}
+	}
+	deletes := 0
+	for _, call := range fake.Calls() {
+		if call.Op == minimaxfake.OpDeleteFile {
+			deletes++
+		}
+	}
+	if deletes != 2 {
+		t.Errorf("deleted %d uploads, want 2", deletes)
+	}
+}
+
+func TestCloneBatchCancel(t *testing.T) {
+	m, fake, dir := newTestModel(t, config.Clone{})
+	fake.SetDelay(minimaxfake.OpUpload, time.Hour)
+	paths := writeSamples(t, dir, 3)
+
+	m.state = stateBrowser
+	for _, path := range paths {
+		m.selected[path] = true
+		m.selectedOrder = append(m.selectedOrder, path)
+	}
+	_, cmd := m.Update(keyMsg("c"))
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		_, ok := msg.(voiceIDPlanMsg)
+		return ok
+	})
+	_, cmd = m.Update(keyMsg("enter"))
+	// 首个文件的上传被无限期延迟，取消应立即中止它并结束批次。
+	m.Update(keyMsg("s"))
+	var finished cloneFinishedMsg
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		f, ok := msg.(cloneFinishedMsg)
+		finished = f
+		return ok
+	})
+
+	if !finished.Aborted || finished.Cancelled != 3 {
+		t.Errorf("finished = %+v, want 3 cancelled", finished)
+	}
+	for i, rec := range exportedRecords(t, m) {
+		if rec.Status != exporter.StatusCancelled || rec.FilePath != paths[i] || rec.ErrorCode != "cancelled" {
+			t.Errorf("row %d = %+v", i, rec)
+		}
+	}
+}
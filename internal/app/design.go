@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+const (
+	designFieldPrompt = iota
+	designFieldPreview
+	designFieldVoiceID
+	designFieldCount
+)
+
+var designFieldLabels = [designFieldCount]string{
+	designFieldPrompt:  "音色描述",
+	designFieldPreview: "试听文本",
+	designFieldVoiceID: "Voice ID（可选）",
+}
+
+type designForm struct {
+	inputs  []textinput.Model
+	focus   int
+	running bool
+}
+
+type designFinishedMsg struct {
+	Prompt      string
+	Result      *minimax.DesignVoiceResult
+	PreviewPath string
+	Err         error
+}
+
+func (m *model) openDesignView() (tea.Model, tea.Cmd) {
+	inputs := make([]textinput.Model, designFieldCount)
+	placeholders := [designFieldCount]string{
+		designFieldPrompt:  "例如：温柔的年轻女性，语速偏慢，适合睡前故事",
+		designFieldPreview: "用于生成试听音频的文本",
+		designFieldVoiceID: "留空则由 MiniMax 生成",
+	}
+	for i := range inputs {
+		inputs[i] = textinput.New()
+		inputs[i].Placeholder = placeholders[i]
+		inputs[i].Prompt = ""
+		inputs[i].CharLimit = 0
+	}
+	inputs[designFieldPreview].SetValue(m.cfg.Clone.PreviewText)
+
+	m.design = designForm{inputs: inputs}
+	m.state = stateDesign
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, m.focusDesignField(designFieldPrompt)
+}
+
+func (m *model) focusDesignField(field int) tea.Cmd {
+	m.design.focus = field
+	var cmd tea.Cmd
+	for i := range m.design.inputs {
+		if i == field {
+			cmd = m.design.inputs[i].Focus()
+		} else {
+			m.design.inputs[i].Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *model) updateDesignKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.design.running {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.state = stateBrowser
+		m.errorMsg = ""
+		m.infoMsg = ""
+		return m, m.loadDirectoryCmd(m.currentDirOrRoot())
+	case "tab", "down":
+		return m, m.focusDesignField((m.design.focus + 1) % designFieldCount)
+	case "shift+tab", "up":
+		return m, m.focusDesignField((m.design.focus + designFieldCount - 1) % designFieldCount)
+	case "enter":
+		req := minimax.DesignVoiceRequest{
+			Prompt:      strings.TrimSpace(m.design.inputs[designFieldPrompt].Value()),
+			PreviewText: strings.TrimSpace(m.design.inputs[designFieldPreview].Value()),
+			VoiceID:     strings.TrimSpace(m.design.inputs[designFieldVoiceID].Value()),
+		}
+		if req.Prompt == "" || req.PreviewText == "" {
+			m.errorMsg = "音色描述与试听文本不能为空"
+			return m, nil
+		}
+		m.design.running = true
+		m.errorMsg = ""
+		m.infoMsg = ""
+		return m, tea.Batch(m.spinner.Tick, designVoiceCmd(m.minimax, req, filepath.Join(m.paths.DemosDir, "design")))
+	}
+
+	var cmd tea.Cmd
+	m.design.inputs[m.design.focus], cmd = m.design.inputs[m.design.focus].Update(msg)
+	return m, cmd
+}
+
+func designVoiceCmd(client minimax.VoiceManager, req minimax.DesignVoiceRequest, dir string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.DesignVoice(context.Background(), req)
+		if err != nil {
+			return designFinishedMsg{Prompt: req.Prompt, Err: err}
+		}
+		msg := designFinishedMsg{Prompt: req.Prompt, Result: result}
+		if len(result.TrialAudio) == 0 {
+			return msg
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			msg.Err = fmt.Errorf("ensure design directory: %w", err)
+			return msg
+		}
+		path := filepath.Join(dir, result.VoiceID+".mp3")
+		if err := os.WriteFile(path, result.TrialAudio, 0o644); err != nil {
+			msg.Err = fmt.Errorf("write trial audio: %w", err)
+			return msg
+		}
+		msg.PreviewPath = path
+		return msg
+	}
+}
+
+// handleDesignFinished 将设计结果记入 m.results 并立即导出 CSV：之后开始的批次会清空 m.results，
+// 只有写入导出文件的音色才能出现在混合音色历史与 Voice ID 冲突检查中。
+// 音色已创建但试听音频保存失败时仍记为成功，只提示保存错误。
+func (m *model) handleDesignFinished(msg designFinishedMsg) (tea.Model, tea.Cmd) {
+	m.design.running = false
+	if msg.Result == nil {
+		m.logger.Error().Err(msg.Err).Msg("voice design failed")
+		m.errorMsg = fmt.Sprintf("音色设计失败：%v%s", msg.Err, errorHint(msg.Err))
+		m.recordDesign(exporter.Record{
+			MinimaxVoiceID: strings.TrimSpace(m.design.inputs[designFieldVoiceID].Value()),
+			Status:         exporter.StatusFailed,
+			ErrorReason:    msg.Err.Error(),
+			ErrorCode:      minimax.ErrorCode(msg.Err),
+			UpdatedAt:      time.Now(),
+			VoicePrompt:    msg.Prompt,
+		})
+		return m, nil
+	}
+
+	m.logger.Info().Str("voice_id", msg.Result.VoiceID).Str("preview", msg.PreviewPath).Msg("voice design success")
+	m.errorMsg = ""
+	m.infoMsg = fmt.Sprintf("已生成音色 %s", msg.Result.VoiceID)
+	if msg.PreviewPath != "" {
+		m.infoMsg += fmt.Sprintf("，试听音频：%s", msg.PreviewPath)
+	}
+	m.recordDesign(exporter.Record{
+		MinimaxVoiceID: msg.Result.VoiceID,
+		Status:         exporter.StatusSuccess,
+		UpdatedAt:      time.Now(),
+		CloneAttempts:  msg.Result.Attempts,
+		DemoAudioPath:  msg.PreviewPath,
+		VoicePrompt:    msg.Prompt,
+	})
+	if msg.Err != nil {
+		m.logger.Warn().Err(msg.Err).Str("voice_id", msg.Result.VoiceID).Msg("save trial audio failed")
+		m.errorMsg = fmt.Sprintf("音色 %s 已生成，但试听音频保存失败：%v", msg.Result.VoiceID, msg.Err)
+	}
+	return m, nil
+}
+
+// recordDesign 保存一次音色设计的结果。同一会话的设计记录写入同一个导出文件，
+// 导出失败时只提示，不影响已创建的音色，下次设计时连同本条一并重试。
+func (m *model) recordDesign(rec exporter.Record) {
+	m.results = append(m.results, rec)
+	m.designRecords = append(m.designRecords, rec)
+	var err error
+	if m.designExportPath == "" {
+		m.designExportPath, err = exporter.ToCSV(m.designRecords, m.paths.DownloadsDir)
+	} else {
+		err = exporter.Rewrite(m.designExportPath, m.designRecords)
+	}
+	if err != nil {
+		m.logger.Error().Err(err).Str("voice_id", rec.MinimaxVoiceID).Msg("export design result failed")
+		if m.errorMsg == "" {
+			m.errorMsg = fmt.Sprintf("设计结果导出失败：%v", err)
+		}
+		return
+	}
+	m.lastExportPath = m.designExportPath
+	if rec.Status == exporter.StatusSuccess {
+		m.infoMsg += fmt.Sprintf("，结果已导出：%s", m.designExportPath)
+	}
+}
+
+func (m *model) viewDesign() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render("音色设计"))
+
+	for i, input := range m.design.inputs {
+		label := designFieldLabels[i]
+		if i == m.design.focus {
+			fmt.Fprintf(&b, "%s %s\n", selectedStyle.Render("> "+label+"："), input.View())
+		} else {
+			fmt.Fprintf(&b, "  %s：%s\n", label, input.View())
+		}
+	}
+
+	if m.design.running {
+		fmt.Fprintf(&b, "\n%s 正在生成音色...\n", m.spinner.View())
+	}
+
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render("Tab/↑/↓ 切换字段 · Enter 生成音色 · Esc 返回（结果自动导出到下载目录）"))
+
+	status := ""
+	if m.infoMsg != "" {
+		status = statusStyle.Render(m.infoMsg)
+	}
+	if m.errorMsg != "" {
+		status = errorStyle.Render(m.errorMsg)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, borderStyle.Width(m.width-4).Render(b.String()), status)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+	"minimax/internal/system"
+)
+
+func TestDesignResultsSurviveLaterBatches(t *testing.T) {
+	dir := t.TempDir()
+	m := newModel(config.Config{}, system.Paths{DownloadsDir: dir}, zerolog.Nop(), dir)
+	m.width, m.height = 100, 30
+	m.openDesignView()
+
+	m.handleDesignFinished(designFinishedMsg{
+		Prompt: "温柔的女声",
+		Result: &minimax.DesignVoiceResult{VoiceID: "designed-voice-01", Attempts: 1},
+	})
+	m.handleDesignFinished(designFinishedMsg{Prompt: "低沉的男声", Err: minimax.ErrInsufficientBalance})
+	if m.lastExportPath == "" {
+		t.Fatal("design result was not exported")
+	}
+	// 同一会话的设计结果写入同一个文件，而不是每次设计各生成一个 CSV。
+	if exports, _ := filepath.Glob(filepath.Join(dir, "minimax_voice_export_*")); len(exports) != 1 || exports[0] != m.lastExportPath {
+		t.Errorf("design exports = %v, want only %s", exports, m.lastExportPath)
+	}
+
+	// 随后开始的批次会清空 m.results，设计记录仍应能从导出历史中读到。
+	m.startBatch(batchClone, []string{filepath.Join(dir, "sample.mp3")})
+	if len(m.results) != 0 {
+		t.Fatalf("startBatch kept %d results", len(m.results))
+	}
+	history, err := exporter.LoadHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var success, failed int
+	for _, rec := range history {
+		switch {
+		case rec.MinimaxVoiceID == "designed-voice-01" && rec.Status == exporter.StatusSuccess && rec.VoicePrompt == "温柔的女声":
+			success++
+		case rec.Status == exporter.StatusFailed && rec.ErrorCode == "insufficient_balance" && rec.VoicePrompt == "低沉的男声":
+			failed++
+		}
+	}
+	if success != 1 || failed != 1 {
+		t.Errorf("history has %d designed and %d failed design records: %+v", success, failed, history)
+	}
+	if ids := historyVoiceIDs(nil, history); !slices.Contains(ids, "designed-voice-01") {
+		t.Errorf("blend history %v is missing the designed voice", ids)
+	}
+}
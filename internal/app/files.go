@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/minimax"
+)
+
+// remoteFilePurposes 是远程文件界面展示的文件用途。
+var remoteFilePurposes = []string{minimax.PurposeVoiceClone, minimax.PurposePromptAudio}
+
+type filesLoadedMsg struct {
+	Files []minimax.File
+	Err   error
+}
+
+type fileDeletedMsg struct {
+	File minimax.File
+	Err  error
+}
+
+func (m *model) openFilesView() (tea.Model, tea.Cmd) {
+	m.state = stateFiles
+	m.remoteFiles = nil
+	m.filesCursor = 0
+	m.filesConfirmDelete = false
+	m.filesLoading = true
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, tea.Batch(m.spinner.Tick, listRemoteFilesCmd(m.minimax))
+}
+
+func listRemoteFilesCmd(client minimax.FileManager) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var files []minimax.File
+		for _, purpose := range remoteFilePurposes {
+			list, err := client.ListFiles(ctx, purpose)
+			if err != nil {
+				return filesLoadedMsg{Err: fmt.Errorf("list %s files: %w", purpose, err)}
+			}
+			files = append(files, list...)
+		}
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].CreatedAt > files[j].CreatedAt
+		})
+		return filesLoadedMsg{Files: files}
+	}
+}
+
+func deleteRemoteFileCmd(client minimax.FileManager, file minimax.File) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeleteFile(context.Background(), file.FileID, file.Purpose)
+		return fileDeletedMsg{File: file, Err: err}
+	}
+}
+
+func (m *model) handleFilesLoaded(msg filesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.filesLoading = false
+	if msg.Err != nil {
+		m.logger.Error().Err(msg.Err).Msg("list remote files failed")
+		m.errorMsg = fmt.Sprintf("获取远程文件失败：%v", msg.Err)
+		return m, nil
+	}
+	m.remoteFiles = msg.Files
+	if m.filesCursor >= len(m.remoteFiles) {
+		m.filesCursor = max(len(m.remoteFiles)-1, 0)
+	}
+	m.errorMsg = ""
+	return m, nil
+}
+
+func (m *model) handleFileDeleted(msg fileDeletedMsg) (tea.Model, tea.Cmd) {
+	m.filesLoading = false
+	if msg.Err != nil {
+		m.logger.Error().Err(msg.Err).Int64("file_id", msg.File.FileID).Msg("delete remote file failed")
+		m.errorMsg = fmt.Sprintf("删除文件 %d 失败：%v", msg.File.FileID, msg.Err)
+		return m, nil
+	}
+	m.logger.Info().Int64("file_id", msg.File.FileID).Str("purpose", msg.File.Purpose).Msg("remote file deleted")
+	m.infoMsg = fmt.Sprintf("已删除文件 %d（%s）", msg.File.FileID, msg.File.Filename)
+	m.errorMsg = ""
+	m.filesLoading = true
+	return m, tea.Batch(m.spinner.Tick, listRemoteFilesCmd(m.minimax))
+}
+
+func (m *model) updateFilesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filesConfirmDelete {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "y", "enter":
+			m.filesConfirmDelete = false
+			if m.filesCursor >= len(m.remoteFiles) {
+				return m, nil
+			}
+			m.filesLoading = true
+			return m, tea.Batch(m.spinner.Tick, deleteRemoteFileCmd(m.minimax, m.remoteFiles[m.filesCursor]))
+		case "n", "esc":
+			m.filesConfirmDelete = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.state = stateBrowser
+		m.errorMsg = ""
+		m.infoMsg = ""
+		return m, m.loadDirectoryCmd(m.currentDirOrRoot())
+	case "up", "k":
+		if m.filesCursor > 0 {
+			m.filesCursor--
+		}
+	case "down", "j":
+		if m.filesCursor < len(m.remoteFiles)-1 {
+			m.filesCursor++
+		}
+	case "r":
+		if !m.filesLoading {
+			m.filesLoading = true
+			return m, tea.Batch(m.spinner.Tick, listRemoteFilesCmd(m.minimax))
+		}
+	case "d":
+		if !m.filesLoading && len(m.remoteFiles) > 0 {
+			m.filesConfirmDelete = true
+		}
+	}
+	return m, nil
+}
+
+func (m *model) viewFiles() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render("MiniMax 远程文件"))
+
+	if m.filesLoading {
+		fmt.Fprintf(&b, "%s 正在加载...\n\n", m.spinner.View())
+	}
+
+	if len(m.remoteFiles) == 0 && !m.filesLoading {
+		fmt.Fprintf(&b, "%s\n", helpStyle.Render("账户下暂无已上传的文件"))
+	} else if len(m.remoteFiles) > 0 {
+		fmt.Fprintf(&b, "  %-14s %-13s %10s  %-19s  %s\n", "文件ID", "用途", "大小", "创建时间", "文件名")
+		for i, file := range m.remoteFiles {
+			cursor := "  "
+			if i == m.filesCursor {
+				cursor = "> "
+			}
+			created := time.Unix(file.CreatedAt, 0).Format("2006-01-02 15:04:05")
+			fmt.Fprintf(&b, "%s%-14d %-13s %10s  %-19s  %s\n",
+				cursor, file.FileID, file.Purpose, formatBytes(file.Bytes), created, file.Filename)
+		}
+	}
+
+	if m.filesConfirmDelete && m.filesCursor < len(m.remoteFiles) {
+		file := m.remoteFiles[m.filesCursor]
+		fmt.Fprintf(&b, "\n%s\n", confirmStyle.Render(fmt.Sprintf("确认删除文件 %d（%s）？按 Y 确认 · N 取消", file.FileID, file.Filename)))
+	}
+
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render("↑/↓ 选择 · D 删除 · R 刷新 · Esc/Q 返回"))
+
+	status := ""
+	if m.infoMsg != "" {
+		status = statusStyle.Render(m.infoMsg)
+	}
+	if m.errorMsg != "" {
+		status = errorStyle.Render(m.errorMsg)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, borderStyle.Width(m.width-4).Render(b.String()), status)
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rs/zerolog"
+
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+// batchKind 区分执行界面当前处理的批次类型。
+type batchKind int
+
+const (
+	batchClone batchKind = iota
+	batchLongTTS
+)
+
+func (k batchKind) label() string {
+	if k == batchLongTTS {
+		return "长文本合成"
+	}
+	return "克隆"
+}
+
+// taskStatusMsg 为异步合成任务的一次轮询结果，Since 为任务创建时间。
+type taskStatusMsg struct {
+	Path   string
+	TaskID int64
+	Status string
+	Since  time.Time
+	At     time.Time
+}
+
+// longTTSJob 描述队列中单个脚本文件的异步合成任务。
+type longTTSJob struct {
+	ctx      context.Context
+	path     string
+	req      minimax.SynthesizeRequest
+	dir      string
+	progress minimax.ProgressFunc
+	status   func(taskStatusMsg)
+}
+
+func isScriptFile(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".txt"
+}
+
+func (m *model) selectedScripts() []string {
+	var scripts []string
+	for _, path := range m.selectedFiles() {
+		if isScriptFile(path) {
+			scripts = append(scripts, path)
+		}
+	}
+	return scripts
+}
+
+// startLongTTSBatch 使用试听表单中的参数为每个勾选的脚本创建异步合成任务。
+func (m *model) startLongTTSBatch(req minimax.SynthesizeRequest) (tea.Model, tea.Cmd) {
+	m.longTTSReq = req
+	m.longTTSReq.Text = ""
+	m.errorMsg = ""
+	m.infoMsg = ""
+	dir := filepath.Join(m.paths.TTSDir, time.Now().Format("20060102_150405"))
+	m.longTTSDir = dir
+	intro := fmt.Sprintf("合成参数：Voice ID %s · 模型 %s · 格式 %s · 输出目录 %s",
+		req.VoiceSetting.VoiceID, req.Model, req.AudioSetting.Format, dir)
+	return m, m.startBatch(batchLongTTS, m.selectedScripts(), intro)
+}
+
+func (m *model) longTTSJob(path string) longTTSJob {
+	ch := m.progressCh
+	return longTTSJob{
+		ctx:      m.batchCtx,
+		path:     path,
+		req:      m.longTTSReq,
+		dir:      m.longTTSDir,
+		progress: m.progressReporter(path),
+		status: func(msg taskStatusMsg) {
+			select {
+			case ch <- msg:
+			default:
+			}
+		},
+	}
+}
+
+func (m *model) handleTaskStatus(msg taskStatusMsg) (tea.Model, tea.Cmd) {
+	if m.state == stateCloning && !m.finishedFiles[msg.Path] {
+		m.taskStatuses[msg.Path] = msg
+	}
+	return m, m.listenProgressCmd()
+}
+
+func longTTSCmd(client minimax.Synthesizer, job longTTSJob, logger zerolog.Logger) tea.Cmd {
+	path := job.path
+	return func() tea.Msg {
+		ctx := job.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		timestamp := time.Now()
+		logs := []string{fmt.Sprintf("开始处理脚本：%s", filepath.Base(path))}
+		fail := func(rec exporter.Record, stage string, err error) tea.Msg {
+			rec.FilePath = path
+			rec.MinimaxVoiceID = job.req.VoiceSetting.VoiceID
+			rec.Status = exporter.StatusFailed
+			rec.ErrorReason = err.Error()
+			rec.ErrorCode = minimax.ErrorCode(err)
+			rec.UpdatedAt = time.Now()
+			logs = append(logs, fmt.Sprintf("  ❌ %s：%v%s", stage, err, errorHint(err)))
+			return cloneStepMsg{Path: path, Err: err, Timestamp: timestamp, Logs: logs, Record: &rec}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Error().Err(err).Str("file", path).Msg("read script failed")
+			return fail(exporter.Record{}, "读取脚本失败", fmt.Errorf("read script: %w", err))
+		}
+		req := job.req
+		req.Text = strings.TrimSpace(string(data))
+		logs = append(logs, fmt.Sprintf("  → 正在创建合成任务（%d 字）...", utf8.RuneCountInString(req.Text)))
+
+		task, err := client.CreateSpeechTask(ctx, req)
+		if err != nil {
+			logger.Error().Err(err).Str("file", path).Msg("create speech task failed")
+			return fail(exporter.Record{}, "创建任务失败", err)
+		}
+		taskID := strconv.FormatInt(task.TaskID, 10)
+		created := time.Now()
+		logs = append(logs, fmt.Sprintf("  ✅ 任务已创建，任务ID：%s，等待合成完成...", taskID))
+		job.status(taskStatusMsg{Path: path, TaskID: task.TaskID, Status: task.Status, Since: created, At: created})
+
+		task, err = client.WaitSpeechTask(ctx, task.TaskID, minimax.DefaultPollPolicy(), func(t *minimax.SpeechTask) {
+			job.status(taskStatusMsg{Path: path, TaskID: t.TaskID, Status: t.Status, Since: created, At: time.Now()})
+		})
+		if err != nil {
+			logger.Error().Err(err).Str("file", path).Str("task_id", taskID).Msg("speech task failed")
+			return fail(exporter.Record{TaskID: taskID}, "合成任务失败", err)
+		}
+		fileIDStr := strconv.FormatInt(task.FileID, 10)
+		logs = append(logs, fmt.Sprintf("  ✅ 合成完成（耗时 %s），正在下载音频...", time.Since(created).Round(time.Second)))
+
+		outputPath, _, err := client.DownloadFile(minimax.WithProgress(ctx, job.progress), task.FileID, job.dir)
+		if err != nil {
+			logger.Error().Err(err).Str("file", path).Int64("file_id", task.FileID).Msg("download speech file failed")
+			return fail(exporter.Record{TaskID: taskID, MinimaxFileID: fileIDStr}, "下载音频失败", err)
+		}
+		logs = append(logs, fmt.Sprintf("  🎧 已保存：%s", outputPath))
+
+		logger.Info().Str("file", path).Str("task_id", taskID).Str("output", outputPath).Msg("long text synthesis success")
+		rec := exporter.Record{
+			FilePath:       path,
+			MinimaxFileID:  fileIDStr,
+			MinimaxVoiceID: req.VoiceSetting.VoiceID,
+			Status:         exporter.StatusSuccess,
+			UpdatedAt:      time.Now(),
+			TaskID:         taskID,
+			OutputPath:     outputPath,
+		}
+		return cloneStepMsg{
+			Path:      path,
+			VoiceID:   req.VoiceSetting.VoiceID,
+			Timestamp: time.Now(),
+			Logs:      logs,
+			Record:    &rec,
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"minimax/internal/minimax"
+)
+
+const (
+	progressBarWidth = 24
+	// progressInterval 限制上传进度消息的频率，避免大文件刷屏。
+	progressInterval = 100 * time.Millisecond
+)
+
+type uploadProgressMsg struct {
+	Path  string
+	Sent  int64
+	Total int64
+	At    time.Time
+}
+
+// uploadProgress 记录单个文件的上传进度，startedAt 在每次（重新）开始上传时刷新。
+type uploadProgress struct {
+	sent      int64
+	total     int64
+	startedAt time.Time
+	updatedAt time.Time
+}
+
+func (p *uploadProgress) rate() float64 {
+	elapsed := p.updatedAt.Sub(p.startedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(p.sent) / elapsed
+}
+
+// listenProgressCmd 从进度通道读取一条消息；收到后需再次调用以持续监听。
+func (m *model) listenProgressCmd() tea.Cmd {
+	ch := m.progressCh
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// progressReporter 返回写入进度通道的回调，按 progressInterval 节流，通道已满时丢弃中间进度。
+// 重试时上一次尝试的请求体可能仍在发送，回调会被并发调用，节流状态需加锁。
+func (m *model) progressReporter(path string) minimax.ProgressFunc {
+	ch := m.progressCh
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return func(sent, total int64) {
+		now := time.Now()
+		done := total > 0 && sent >= total
+		mu.Lock()
+		if sent != 0 && !done && now.Sub(last) < progressInterval {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		select {
+		case ch <- uploadProgressMsg{Path: path, Sent: sent, Total: total, At: now}:
+		default:
+		}
+	}
+}
+
+func (m *model) handleUploadProgress(msg uploadProgressMsg) (tea.Model, tea.Cmd) {
+	if m.state == stateTTS && m.tts.running {
+		m.tts.streamed = msg.Sent
+		return m, m.listenProgressCmd()
+	}
+	if m.state == stateCloning && !m.finishedFiles[msg.Path] {
+		p, ok := m.uploads[msg.Path]
+		if !ok || msg.Sent == 0 || msg.Sent < p.sent {
+			p = &uploadProgress{startedAt: msg.At}
+			m.uploads[msg.Path] = p
+		}
+		p.sent = msg.Sent
+		p.total = msg.Total
+		p.updatedAt = msg.At
+	}
+	return m, m.listenProgressCmd()
+}
+
+// resetBatchProgress 在批次开始时统计总字节数，用于计算整体 ETA。
+func (m *model) resetBatchProgress(paths []string) {
+	m.uploads = make(map[string]*uploadProgress)
+	m.taskStatuses = make(map[string]taskStatusMsg)
+	m.finishedFiles = make(map[string]bool)
+	m.fileSizes = make(map[string]int64, len(paths))
+	m.batchBytes = 0
+	m.batchStarted = time.Now()
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			m.fileSizes[path] = info.Size()
+			m.batchBytes += info.Size()
+		}
+	}
+}
+
+func (m *model) markFileFinished(path string) {
+	m.finishedFiles[path] = true
+	delete(m.uploads, path)
+	delete(m.taskStatuses, path)
+}
+
+func (m *model) batchTransferred() int64 {
+	var done int64
+	for path := range m.finishedFiles {
+		done += m.fileSizes[path]
+	}
+	for _, p := range m.uploads {
+		done += p.sent
+	}
+	return done
+}
+
+func (m *model) viewUploadProgress() string {
+	var b strings.Builder
+	for _, path := range m.cloneQueue {
+		if task, ok := m.taskStatuses[path]; ok {
+			if _, downloading := m.uploads[path]; !downloading {
+				fmt.Fprintf(&b, "%s 任务 %d · %s · 已等待 %s\n",
+					filepath.Base(path), task.TaskID, task.Status, task.At.Sub(task.Since).Round(time.Second))
+			}
+		}
+		p, ok := m.uploads[path]
+		if !ok {
+			continue
+		}
+		ratio := 0.0
+		if p.total > 0 {
+			ratio = float64(p.sent) / float64(p.total)
+		}
+		status := fmt.Sprintf("%s/s", formatBytes(int64(p.rate())))
+		if p.total > 0 && p.sent >= p.total {
+			status = "等待服务端处理..."
+		}
+		fmt.Fprintf(&b, "%s %s %3.0f%% %s/%s · %s\n",
+			progressBar(ratio, progressBarWidth),
+			filepath.Base(path),
+			ratio*100,
+			formatBytes(p.sent),
+			formatBytes(p.total),
+			status,
+		)
+	}
+
+	if m.batchKind != batchClone {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+
+	transferred := m.batchTransferred()
+	eta := "--"
+	if elapsed := time.Since(m.batchStarted).Seconds(); transferred > 0 && elapsed > 0 {
+		rate := float64(transferred) / elapsed
+		if remaining := m.batchBytes - transferred; remaining > 0 {
+			eta = time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second).String()
+		} else {
+			eta = "0s"
+		}
+	}
+	fmt.Fprintf(&b, "总进度：%s/%s · 预计剩余 %s", formatBytes(transferred), formatBytes(m.batchBytes), eta)
+	return b.String()
+}
+
+// rateWaitMsg 表示某个请求正在等待客户端限速令牌，Until 为预计可发出的时间。
+type rateWaitMsg struct {
+	Class minimax.EndpointClass
+	Until time.Time
+}
+
+// rateLimitObserver 将限速等待转发到进度通道，通道已满时丢弃。
+func (m *model) rateLimitObserver() minimax.RateLimitObserver {
+	ch := m.progressCh
+	return func(class minimax.EndpointClass, wait time.Duration) {
+		select {
+		case ch <- rateWaitMsg{Class: class, Until: time.Now().Add(wait)}:
+		default:
+		}
+	}
+}
+
+func (m *model) handleRateWait(msg rateWaitMsg) (tea.Model, tea.Cmd) {
+	if msg.Until.After(m.rateWait.Until) {
+		m.rateWait = msg
+	}
+	return m, m.listenProgressCmd()
+}
+
+func (m *model) viewRateWait() string {
+	remaining := time.Until(m.rateWait.Until)
+	if remaining <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("⏳ 客户端限速：%s 类请求等待中，约 %s 后发出", m.rateWait.Class, remaining.Round(100*time.Millisecond))
+}
+
+func progressBar(ratio float64, width int) string {
+	ratio = max(0, min(ratio, 1))
+	filled := int(ratio * float64(width))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 上一次尝试的请求体仍在发送时重试已开始，两次尝试会并发调用同一个回调，需配合 -race 运行。
+func TestProgressReporterConcurrentAttempts(t *testing.T) {
+	m := &model{progressCh: make(chan tea.Msg, 1024)}
+	report := m.progressReporter("sample.mp3")
+
+	const total = 1000
+	var wg sync.WaitGroup
+	for attempt := 0; attempt < 4; attempt++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sent := int64(0); sent <= total; sent += 10 {
+				report(sent, total)
+			}
+		}()
+	}
+	wg.Wait()
+	close(m.progressCh)
+
+	starts, finishes := 0, 0
+	for msg := range m.progressCh {
+		p := msg.(uploadProgressMsg)
+		switch p.Sent {
+		case 0:
+			starts++
+		case total:
+			finishes++
+		}
+	}
+	// 起始与完成的进度不受节流影响，每次尝试都应送达。
+	if starts != 4 || finishes != 4 {
+		t.Errorf("got %d start and %d finish updates, want 4 each", starts, finishes)
+	}
+}
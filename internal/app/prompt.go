@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptSuffix 是批量输入时提示音频与文本的约定后缀：
+// sample.wav 对应 sample.prompt.wav（或 mp3/m4a）与 sample.prompt.txt。
+const promptSuffix = ".prompt"
+
+var audioExts = []string{".wav", ".mp3", ".m4a"}
+
+// promptPair 记录与主样本配对的 clone_prompt 提示音频及其文本。
+type promptPair struct {
+	AudioPath string
+	Text      string
+}
+
+func isAudioFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, candidate := range audioExts {
+		if ext == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// findSidecarPrompt 在样本同目录下查找约定命名的提示音频与文本。
+func findSidecarPrompt(samplePath string) (promptPair, bool) {
+	base := strings.TrimSuffix(samplePath, filepath.Ext(samplePath)) + promptSuffix
+
+	data, err := os.ReadFile(base + ".txt")
+	if err != nil {
+		return promptPair{}, false
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return promptPair{}, false
+	}
+
+	for _, ext := range audioExts {
+		audioPath := base + ext
+		if info, err := os.Stat(audioPath); err == nil && !info.IsDir() {
+			return promptPair{AudioPath: audioPath, Text: text}, true
+		}
+	}
+	return promptPair{}, false
+}
+
+// promptFor 返回样本的提示音频配对，界面中手动配对优先于约定命名的文件。
+func (m *model) promptFor(samplePath string) (promptPair, bool) {
+	if pair, ok := m.prompts[samplePath]; ok {
+		return pair, true
+	}
+	return findSidecarPrompt(samplePath)
+}
+
+// cloneSamples 返回要克隆的主样本：已作为其他勾选样本提示音频的文件不单独克隆。
+func (m *model) cloneSamples() []string {
+	selected := m.selectedFiles()
+	prompts := make(map[string]bool)
+	for _, path := range selected {
+		if pair, ok := m.promptFor(path); ok {
+			prompts[pair.AudioPath] = true
+		}
+	}
+	samples := make([]string, 0, len(selected))
+	for _, path := range selected {
+		if !prompts[path] {
+			samples = append(samples, path)
+		}
+	}
+	return samples
+}
+
+// lastSelectedSample 返回最近勾选的主样本，作为提示音频配对的目标。
+func (m *model) lastSelectedSample() string {
+	for i := len(m.selectedOrder) - 1; i >= 0; i-- {
+		if path := m.selectedOrder[i]; m.selected[path] {
+			return path
+		}
+	}
+	return ""
+}
+
+func (m *model) beginPromptPairing(item fileItem) (tea.Model, tea.Cmd) {
+	if item.isDir || !isAudioFile(item.path) {
+		m.errorMsg = "提示音频仅支持 mp3、m4a、wav 文件"
+		return m, nil
+	}
+	target := m.lastSelectedSample()
+	if target == "" {
+		m.errorMsg = "请先勾选要配对的主样本，再在提示音频上按 P"
+		return m, nil
+	}
+	if target == item.path {
+		m.errorMsg = "提示音频不能与主样本为同一文件"
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = "提示音频对应的文本"
+	input.Prompt = ""
+	input.CharLimit = 500
+	if pair, ok := m.prompts[target]; ok && pair.AudioPath == item.path {
+		input.SetValue(pair.Text)
+	}
+
+	m.promptTarget = target
+	m.promptAudio = item.path
+	m.promptInput = input
+	m.errorMsg = ""
+	m.state = statePrompt
+	return m, m.promptInput.Focus()
+}
+
+func (m *model) clearPromptPairing() (tea.Model, tea.Cmd) {
+	target := m.lastSelectedSample()
+	if _, ok := m.prompts[target]; !ok {
+		m.errorMsg = "最近勾选的样本没有配对提示音频"
+		return m, nil
+	}
+	delete(m.prompts, target)
+	m.statusMsg = fmt.Sprintf("已取消 %s 的提示音频配对", filepath.Base(target))
+	m.errorMsg = ""
+	return m, nil
+}
+
+func (m *model) updatePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.state = stateBrowser
+		m.errorMsg = ""
+		return m, nil
+	case "enter":
+		text := strings.TrimSpace(m.promptInput.Value())
+		if text == "" {
+			m.errorMsg = "请填写提示音频对应的文本"
+			return m, nil
+		}
+		m.prompts[m.promptTarget] = promptPair{AudioPath: m.promptAudio, Text: text}
+		m.state = stateBrowser
+		m.statusMsg = fmt.Sprintf("已为 %s 配对提示音频 %s", filepath.Base(m.promptTarget), filepath.Base(m.promptAudio))
+		m.errorMsg = ""
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return m, cmd
+}
+
+func (m *model) viewPrompt() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render("配对提示音频（clone_prompt）"))
+	fmt.Fprintf(&b, "主样本：%s\n", m.displayPath(m.promptTarget))
+	fmt.Fprintf(&b, "提示音频：%s\n\n", m.displayPath(m.promptAudio))
+	fmt.Fprintf(&b, "提示文本\n%s\n\n", selectedStyle.Render(m.promptInput.View()))
+	fmt.Fprintf(&b, "%s\n", helpStyle.Render("提示音频建议短于 8 秒，文本需与音频内容一致 · Enter 保存 · Esc 取消"))
+	if m.errorMsg != "" {
+		fmt.Fprintf(&b, "\n%s\n", errorStyle.Render(m.errorMsg))
+	}
+	return borderStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,407 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+// ttsField 为 TTS 试听界面的表单项，按界面展示顺序排列。
+type ttsField int
+
+const (
+	ttsFieldVoice ttsField = iota
+	ttsFieldText
+	ttsFieldModel
+	ttsFieldSpeed
+	ttsFieldVol
+	ttsFieldPitch
+	ttsFieldEmotion
+	ttsFieldFormat
+	ttsFieldSampleRate
+	ttsFieldStream
+	ttsFieldCount
+)
+
+var ttsFieldLabels = map[ttsField]string{
+	ttsFieldVoice:      "Voice ID",
+	ttsFieldText:       "合成文本",
+	ttsFieldModel:      "模型",
+	ttsFieldSpeed:      "语速 (0.5~2)",
+	ttsFieldVol:        "音量 (0~10)",
+	ttsFieldPitch:      "音调 (-12~12)",
+	ttsFieldEmotion:    "情绪",
+	ttsFieldFormat:     "输出格式",
+	ttsFieldSampleRate: "采样率",
+	ttsFieldStream:     "输出方式",
+}
+
+type ttsForm struct {
+	inputs     map[ttsField]*textinput.Model
+	focus      ttsField
+	model      string
+	emotion    string
+	format     string
+	sampleRate int
+	voiceIndex int
+	running    bool
+	lastPath   string
+	// stream 为真时使用流式合成，音频边生成边写入文件，streamed 为已写入字节数。
+	stream   bool
+	streamed int64
+	// async 表示表单用于长文本批量合成，文本来自勾选的 txt 脚本。
+	async bool
+}
+
+type ttsFinishedMsg struct {
+	Path   string
+	Result *minimax.SynthesizeResult
+	Err    error
+}
+
+func newTTSInput(placeholder, value string) *textinput.Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.Prompt = ""
+	input.CharLimit = 0
+	input.SetValue(value)
+	return &input
+}
+
+// resultVoiceIDs 返回本次会话中克隆成功的 Voice ID，供试听界面快速选择。
+func (m *model) resultVoiceIDs() []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(m.results))
+	for _, rec := range m.results {
+		if rec.Status != exporter.StatusSuccess || rec.MinimaxVoiceID == "" || seen[rec.MinimaxVoiceID] {
+			continue
+		}
+		seen[rec.MinimaxVoiceID] = true
+		ids = append(ids, rec.MinimaxVoiceID)
+	}
+	return ids
+}
+
+func (m *model) openTTSView(async bool) (tea.Model, tea.Cmd) {
+	voice := ""
+	if ids := m.resultVoiceIDs(); len(ids) > 0 {
+		voice = ids[0]
+	}
+	m.tts = ttsForm{
+		inputs: map[ttsField]*textinput.Model{
+			ttsFieldVoice: newTTSInput("Voice ID（Ctrl+N 在克隆结果间切换）", voice),
+			ttsFieldText:  newTTSInput("要合成的文本", ""),
+			ttsFieldSpeed: newTTSInput("1.0", ""),
+			ttsFieldVol:   newTTSInput("1.0", ""),
+			ttsFieldPitch: newTTSInput("0", ""),
+		},
+		model:      minimax.DefaultModel,
+		format:     minimax.DefaultAudioFormat,
+		sampleRate: minimax.DefaultSampleRate,
+		async:      async,
+	}
+	if async {
+		delete(m.tts.inputs, ttsFieldText)
+	}
+	m.state = stateTTS
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, m.focusTTSField(ttsFieldVoice)
+}
+
+func (m *model) focusTTSField(field ttsField) tea.Cmd {
+	m.tts.focus = field
+	var cmd tea.Cmd
+	for f, input := range m.tts.inputs {
+		if f == field {
+			cmd = input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+	return cmd
+}
+
+func (m *model) updateTTSKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.tts.running {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.state = stateBrowser
+		m.errorMsg = ""
+		m.infoMsg = ""
+		return m, m.loadDirectoryCmd(m.currentDirOrRoot())
+	case "tab", "down":
+		return m, m.focusTTSField((m.tts.focus + 1) % ttsFieldCount)
+	case "shift+tab", "up":
+		return m, m.focusTTSField((m.tts.focus + ttsFieldCount - 1) % ttsFieldCount)
+	case "enter":
+		return m.startSynthesis()
+	case "ctrl+n":
+		if m.tts.focus == ttsFieldVoice {
+			if ids := m.resultVoiceIDs(); len(ids) > 0 {
+				m.tts.voiceIndex = (m.tts.voiceIndex + 1) % len(ids)
+				m.tts.inputs[ttsFieldVoice].SetValue(ids[m.tts.voiceIndex])
+			}
+			return m, nil
+		}
+	case "left", "right":
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		switch m.tts.focus {
+		case ttsFieldModel:
+			m.tts.model = cycle(minimax.Models, m.tts.model, delta)
+			return m, nil
+		case ttsFieldEmotion:
+			m.tts.emotion = cycle(minimax.Emotions, m.tts.emotion, delta)
+			return m, nil
+		case ttsFieldFormat:
+			m.tts.format = cycle(m.ttsFormats(), m.tts.format, delta)
+			return m, nil
+		case ttsFieldStream:
+			if m.tts.async {
+				return m, nil
+			}
+			m.tts.stream = !m.tts.stream
+			if !slices.Contains(m.ttsFormats(), m.tts.format) {
+				m.tts.format = minimax.DefaultAudioFormat
+			}
+			return m, nil
+		case ttsFieldSampleRate:
+			m.tts.sampleRate = cycle(minimax.SampleRates, m.tts.sampleRate, delta)
+			return m, nil
+		}
+	}
+
+	if input, ok := m.tts.inputs[m.tts.focus]; ok {
+		var cmd tea.Cmd
+		*input, cmd = input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// ttsFormats 返回当前输出方式可选的音频格式。
+func (m *model) ttsFormats() []string {
+	if m.tts.stream {
+		return minimax.StreamFormats
+	}
+	return minimax.AudioFormats
+}
+
+func cycle[T comparable](values []T, current T, delta int) T {
+	for i, v := range values {
+		if v == current {
+			return values[(i+delta+len(values))%len(values)]
+		}
+	}
+	return values[0]
+}
+
+func (m *model) ttsRequest() (minimax.SynthesizeRequest, error) {
+	req := minimax.SynthesizeRequest{
+		Model: m.tts.model,
+		VoiceSetting: minimax.VoiceSetting{
+			VoiceID: strings.TrimSpace(m.tts.inputs[ttsFieldVoice].Value()),
+			Emotion: m.tts.emotion,
+		},
+		AudioSetting: minimax.AudioSetting{
+			Format:     m.tts.format,
+			SampleRate: m.tts.sampleRate,
+		},
+	}
+
+	// 长文本模式下文本在执行时读取脚本，这里仅用占位文本校验其余参数。
+	req.Text = "-"
+	if input, ok := m.tts.inputs[ttsFieldText]; ok {
+		req.Text = strings.TrimSpace(input.Value())
+	}
+
+	var err error
+	if v := strings.TrimSpace(m.tts.inputs[ttsFieldSpeed].Value()); v != "" {
+		if req.VoiceSetting.Speed, err = strconv.ParseFloat(v, 64); err != nil {
+			return req, fmt.Errorf("语速必须是数字")
+		}
+	}
+	if v := strings.TrimSpace(m.tts.inputs[ttsFieldVol].Value()); v != "" {
+		if req.VoiceSetting.Vol, err = strconv.ParseFloat(v, 64); err != nil {
+			return req, fmt.Errorf("音量必须是数字")
+		}
+	}
+	if v := strings.TrimSpace(m.tts.inputs[ttsFieldPitch].Value()); v != "" {
+		if req.VoiceSetting.Pitch, err = strconv.Atoi(v); err != nil {
+			return req, fmt.Errorf("音调必须是整数")
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (m *model) startSynthesis() (tea.Model, tea.Cmd) {
+	req, err := m.ttsRequest()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return m, nil
+	}
+	if m.tts.async {
+		return m.startLongTTSBatch(req)
+	}
+	m.tts.running = true
+	m.tts.streamed = 0
+	m.errorMsg = ""
+	m.infoMsg = ""
+	if m.tts.stream {
+		path := ttsOutputPath(m.paths.TTSDir, req.VoiceSetting.VoiceID, req.AudioSetting.Format)
+		return m, tea.Batch(m.spinner.Tick, synthesizeStreamCmd(m.minimax, req, path, m.progressReporter(path)))
+	}
+	return m, tea.Batch(m.spinner.Tick, synthesizeCmd(m.minimax, req, m.paths.TTSDir))
+}
+
+func ttsOutputPath(dir, voiceID, format string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s_%s.%s", voiceID, time.Now().Format("20060102_150405"), format))
+}
+
+func synthesizeCmd(client minimax.Synthesizer, req minimax.SynthesizeRequest, dir string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.Synthesize(context.Background(), req)
+		if err != nil {
+			return ttsFinishedMsg{Err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return ttsFinishedMsg{Err: fmt.Errorf("ensure tts directory: %w", err)}
+		}
+		path := ttsOutputPath(dir, req.VoiceSetting.VoiceID, result.Format)
+		if err := os.WriteFile(path, result.Audio, 0o644); err != nil {
+			return ttsFinishedMsg{Err: fmt.Errorf("write audio file: %w", err)}
+		}
+		return ttsFinishedMsg{Path: path, Result: result}
+	}
+}
+
+// synthesizeStreamCmd 将流式合成的音频直接写入 path，生成过程中即可开始播放；失败时删除不完整的文件。
+func synthesizeStreamCmd(client minimax.Synthesizer, req minimax.SynthesizeRequest, path string, progress minimax.ProgressFunc) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return ttsFinishedMsg{Err: fmt.Errorf("ensure tts directory: %w", err)}
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return ttsFinishedMsg{Err: fmt.Errorf("create audio file: %w", err)}
+		}
+		result, err := client.SynthesizeStream(minimax.WithProgress(context.Background(), progress), req, file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("write audio file: %w", closeErr)
+		}
+		if err != nil {
+			os.Remove(path)
+			return ttsFinishedMsg{Err: err}
+		}
+		return ttsFinishedMsg{Path: path, Result: result}
+	}
+}
+
+func (m *model) handleTTSFinished(msg ttsFinishedMsg) (tea.Model, tea.Cmd) {
+	m.tts.running = false
+	if msg.Err != nil {
+		m.logger.Error().Err(msg.Err).Msg("synthesize failed")
+		m.errorMsg = fmt.Sprintf("合成失败：%v%s", msg.Err, errorHint(msg.Err))
+		return m, nil
+	}
+	m.tts.lastPath = msg.Path
+	m.logger.Info().Str("path", msg.Path).Str("trace_id", msg.Result.TraceID).Msg("synthesize success")
+	m.infoMsg = fmt.Sprintf("已保存：%s（时长 %.1f 秒）", msg.Path, float64(msg.Result.AudioLength)/1000)
+	m.errorMsg = ""
+	return m, nil
+}
+
+func (m *model) ttsFieldValue(field ttsField) string {
+	if field == ttsFieldText && m.tts.async {
+		return fmt.Sprintf("已勾选 %d 个 txt 脚本（单个不超过 %d 字）", len(m.selectedScripts()), minimax.MaxAsyncTextLength)
+	}
+	if input, ok := m.tts.inputs[field]; ok {
+		return input.View()
+	}
+	switch field {
+	case ttsFieldModel:
+		return "◀ " + m.tts.model + " ▶"
+	case ttsFieldEmotion:
+		if m.tts.emotion == "" {
+			return "◀ 自动 ▶"
+		}
+		return "◀ " + m.tts.emotion + " ▶"
+	case ttsFieldFormat:
+		return "◀ " + m.tts.format + " ▶"
+	case ttsFieldSampleRate:
+		return "◀ " + strconv.Itoa(m.tts.sampleRate) + " ▶"
+	case ttsFieldStream:
+		if m.tts.async {
+			return "异步任务"
+		}
+		if m.tts.stream {
+			return "◀ 流式（边生成边写入） ▶"
+		}
+		return "◀ 非流式 ▶"
+	}
+	return ""
+}
+
+func (m *model) viewTTS() string {
+	var b strings.Builder
+	title, help := "TTS 试听", "Tab/↑/↓ 切换字段 · ←/→ 切换选项 · Ctrl+N 切换克隆结果 · Enter 合成并保存 · Esc 返回"
+	if m.tts.async {
+		title, help = "长文本异步合成", "Tab/↑/↓ 切换字段 · ←/→ 切换选项 · Ctrl+N 切换克隆结果 · Enter 提交全部脚本 · Esc 返回"
+	}
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render(title))
+
+	for field := ttsField(0); field < ttsFieldCount; field++ {
+		label := ttsFieldLabels[field]
+		value := m.ttsFieldValue(field)
+		if field == m.tts.focus {
+			fmt.Fprintf(&b, "%s %s\n", selectedStyle.Render("> "+label+"："), selectedStyle.Render(value))
+		} else {
+			fmt.Fprintf(&b, "  %s：%s\n", label, value)
+		}
+	}
+
+	if m.tts.running {
+		if m.tts.stream {
+			fmt.Fprintf(&b, "\n%s 正在流式合成，已写入 %s...\n", m.spinner.View(), formatBytes(m.tts.streamed))
+		} else {
+			fmt.Fprintf(&b, "\n%s 正在合成...\n", m.spinner.View())
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render(help))
+
+	status := ""
+	if m.infoMsg != "" {
+		status = statusStyle.Render(m.infoMsg)
+	}
+	if m.errorMsg != "" {
+		status = errorStyle.Render(m.errorMsg)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, borderStyle.Width(m.width-4).Render(b.String()), status)
+}
@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+)
+
+// voiceIDPlanMsg 为克隆前的 Voice ID 预检结果：IDs 按文件路径给出建议的 ID，Errors 为无法生成 ID 的文件，
+// Taken 为账户与历史中已占用的 ID，Notes 记录改名与检查过程中的提示，开始克隆时写入执行日志。
+type voiceIDPlanMsg struct {
+	Seq    int
+	IDs    map[string]string
+	Errors map[string]error
+	Taken  map[string]bool
+	Notes  []string
+}
+
+// confirmRow 为确认界面表格中的一行。proposed 为预检建议的 ID，voiceID 为当前（可能经手动修改的）ID。
+type confirmRow struct {
+	path     string
+	proposed string
+	voiceID  string
+	planErr  string
+	err      string
+}
+
+// voiceIDStrategy 按配置构造 voice_id 生成策略，未配置时使用哈希策略。
+func voiceIDStrategy(c config.VoiceID) (minimax.VoiceIDStrategy, error) {
+	hash := minimax.HashStrategy{Prefix: c.Prefix, Length: c.HashLength}
+	switch strings.ToLower(strings.TrimSpace(c.Strategy)) {
+	case "", minimax.VoiceIDStrategyHash:
+		return hash, nil
+	case minimax.VoiceIDStrategySlug:
+		return minimax.SlugStrategy{Prefix: c.Prefix}, nil
+	case minimax.VoiceIDStrategyTemplate:
+		if strings.TrimSpace(c.Template) == "" {
+			return nil, fmt.Errorf("voice_id.template is required for the template strategy")
+		}
+		return minimax.TemplateStrategy{Template: c.Template}, nil
+	case minimax.VoiceIDStrategyManifest:
+		if strings.TrimSpace(c.Manifest) == "" {
+			return nil, fmt.Errorf("voice_id.manifest is required for the manifest strategy")
+		}
+		ids, err := minimax.LoadManifest(c.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		return minimax.ManifestStrategy{IDs: ids, Fallback: hash}, nil
+	}
+	return nil, fmt.Errorf("unknown voice_id strategy %q", c.Strategy)
+}
+
+// describeVoiceIDConfig 返回确认界面展示的 Voice ID 规则说明。
+func describeVoiceIDConfig(c config.VoiceID) string {
+	prefix := c.Prefix
+	switch strings.ToLower(strings.TrimSpace(c.Strategy)) {
+	case minimax.VoiceIDStrategySlug:
+		return fmt.Sprintf("文件名（前缀 %q）", prefix)
+	case minimax.VoiceIDStrategyTemplate:
+		return fmt.Sprintf("模板 %s", c.Template)
+	case minimax.VoiceIDStrategyManifest:
+		return fmt.Sprintf("清单 %s", c.Manifest)
+	}
+	if prefix == "" {
+		prefix = minimax.DefaultVoiceIDPrefix
+	}
+	length := c.HashLength
+	if length <= 0 {
+		length = minimax.DefaultHashLength
+	}
+	return fmt.Sprintf("内容哈希（前缀 %s，%d 位）", prefix, min(length, 32))
+}
+
+// planVoiceIDsCmd 为批次中的文件生成 Voice ID，并与账户现有音色、历史导出及本次会话的记录比对，
+// 已被占用的 ID 自动追加序号。无法获取远端或历史列表时只记录提示，不阻止克隆。
+func planVoiceIDsCmd(seq int, client minimax.VoiceManager, strategy minimax.VoiceIDStrategy, paths []string, downloadsDir string, session []exporter.Record) tea.Cmd {
+	return func() tea.Msg {
+		msg := voiceIDPlanMsg{Seq: seq, IDs: make(map[string]string, len(paths)), Errors: make(map[string]error)}
+		proposed := make([]string, len(paths))
+		for i, path := range paths {
+			id, err := strategy.VoiceID(path, i+1)
+			if err != nil {
+				msg.Errors[path] = err
+				continue
+			}
+			proposed[i] = id
+		}
+
+		taken, err := minimax.ExistingVoiceIDs(context.Background(), client)
+		if err != nil {
+			msg.Notes = append(msg.Notes, fmt.Sprintf("⚠️ 无法获取账户音色列表，仅按历史记录检查 Voice ID：%v", err))
+			taken = make(map[string]bool)
+		}
+		history, err := exporter.LoadHistory(downloadsDir)
+		if err != nil {
+			msg.Notes = append(msg.Notes, fmt.Sprintf("⚠️ 读取历史导出失败：%v", err))
+		}
+		for _, rec := range append(append([]exporter.Record(nil), session...), history...) {
+			if rec.MinimaxVoiceID != "" && rec.TaskID == "" && rec.Status != exporter.StatusFailed {
+				taken[rec.MinimaxVoiceID] = true
+			}
+		}
+		msg.Taken = make(map[string]bool, len(taken))
+		for id := range taken {
+			msg.Taken[id] = true
+		}
+
+		for i, path := range paths {
+			if proposed[i] == "" {
+				continue
+			}
+			id := minimax.UniqueVoiceID(proposed[i], taken)
+			if id != proposed[i] {
+				msg.Notes = append(msg.Notes, fmt.Sprintf("Voice ID %s 已存在，%s 改用 %s", proposed[i], filepath.Base(path), id))
+			}
+			taken[id] = true
+			msg.IDs[path] = id
+		}
+		return msg
+	}
+}
+
+// openConfirmView 进入确认界面并在后台生成 Voice ID，预检完成前表格中的 ID 显示为“生成中”。
+func (m *model) openConfirmView() tea.Cmd {
+	paths := m.cloneSamples()
+	m.confirmRows = make([]confirmRow, len(paths))
+	for i, path := range paths {
+		m.confirmRows[i] = confirmRow{path: path}
+	}
+	m.confirmCursor = 0
+	m.voiceIDEditing = false
+	m.voiceIDTaken = nil
+	m.voiceIDNotes = nil
+	m.voiceIDPlanSeq++
+
+	strategy, err := voiceIDStrategy(m.cfg.VoiceID)
+	if err != nil {
+		m.voiceIDPlanning = false
+		m.errorMsg = fmt.Sprintf("Voice ID 规则配置无效：%v（可按 E 逐个填写）", err)
+		for i := range m.confirmRows {
+			m.confirmRows[i].planErr = "未生成 Voice ID"
+		}
+		m.validateConfirmRows()
+		return nil
+	}
+	m.voiceIDPlanning = true
+	return tea.Batch(m.spinner.Tick, planVoiceIDsCmd(m.voiceIDPlanSeq, m.minimax, strategy, paths, m.paths.DownloadsDir, m.results))
+}
+
+func (m *model) handleVoiceIDPlan(msg voiceIDPlanMsg) (tea.Model, tea.Cmd) {
+	if m.state != stateConfirm || msg.Seq != m.voiceIDPlanSeq {
+		return m, nil
+	}
+	m.voiceIDPlanning = false
+	m.voiceIDTaken = msg.Taken
+	m.voiceIDNotes = msg.Notes
+	for _, note := range msg.Notes {
+		m.logger.Info().Str("note", note).Msg("voice id preflight")
+	}
+	for i := range m.confirmRows {
+		row := &m.confirmRows[i]
+		if err, ok := msg.Errors[row.path]; ok {
+			m.logger.Error().Err(err).Str("file", row.path).Msg("generate voice id failed")
+			row.planErr = fmt.Sprintf("生成 Voice ID 失败：%v", err)
+			continue
+		}
+		// 预检期间已手动填写的 ID 保持不变。
+		row.proposed = msg.IDs[row.path]
+		if row.voiceID == "" {
+			row.voiceID = row.proposed
+		}
+	}
+	m.validateConfirmRows()
+	return m, nil
+}
+
+// validateConfirmRows 逐行检查 Voice ID：命名规则、批次内重复以及与已有音色的冲突。
+func (m *model) validateConfirmRows() int {
+	firstRow := make(map[string]int)
+	invalid := 0
+	for i := range m.confirmRows {
+		row := &m.confirmRows[i]
+		row.err = ""
+		switch {
+		case row.voiceID == "" && row.planErr != "":
+			row.err = row.planErr
+		case row.voiceID == "":
+			if !m.voiceIDPlanning {
+				row.err = "Voice ID 不能为空"
+			}
+		default:
+			if err := minimax.ValidateVoiceID(row.voiceID); err != nil {
+				row.err = fmt.Sprintf("不符合命名规则：%v", err)
+			} else if prev, ok := firstRow[row.voiceID]; ok {
+				row.err = fmt.Sprintf("与第 %d 行重复", prev+1)
+			} else if m.voiceIDTaken[row.voiceID] {
+				row.err = "该 Voice ID 已存在于账户或历史记录中"
+			}
+			if _, ok := firstRow[row.voiceID]; !ok {
+				firstRow[row.voiceID] = i
+			}
+		}
+		if row.err != "" {
+			invalid++
+		}
+	}
+	return invalid
+}
+
+func (m *model) startVoiceIDEdit() tea.Cmd {
+	if len(m.confirmRows) == 0 {
+		return nil
+	}
+	input := textinput.New()
+	input.Placeholder = "字母开头，8~256 位字母、数字、- 或 _"
+	input.Prompt = ""
+	input.CharLimit = minimax.MaxVoiceIDLength
+	input.SetValue(m.confirmRows[m.confirmCursor].voiceID)
+	input.CursorEnd()
+	m.voiceIDInput = input
+	m.voiceIDEditing = true
+	return m.voiceIDInput.Focus()
+}
+
+func (m *model) updateVoiceIDInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.voiceIDInput.Blur()
+		m.voiceIDEditing = false
+		return m, nil
+	case "enter":
+		m.confirmRows[m.confirmCursor].voiceID = strings.TrimSpace(m.voiceIDInput.Value())
+		m.voiceIDInput.Blur()
+		m.voiceIDEditing = false
+		m.validateConfirmRows()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.voiceIDInput, cmd = m.voiceIDInput.Update(msg)
+	return m, cmd
+}
+
+// startCloneBatch 将确认表格中的 Voice ID 交给克隆任务，手动修改过的 ID 记入执行日志。
+func (m *model) startCloneBatch() (tea.Model, tea.Cmd) {
+	if m.voiceIDPlanning {
+		m.errorMsg = "Voice ID 仍在生成中，请稍候"
+		return m, nil
+	}
+	if invalid := m.validateConfirmRows(); invalid > 0 {
+		m.errorMsg = fmt.Sprintf("有 %d 个 Voice ID 需要修正（按 E 编辑）", invalid)
+		return m, nil
+	}
+
+	intro := append([]string{
+		fmt.Sprintf("克隆参数：%s · 成功后删除上传文件 %s", describeCloneOptions(m.cloneOpts), onOff(m.deleteUploaded)),
+	}, m.voiceIDNotes...)
+	m.voiceIDs = make(map[string]string, len(m.confirmRows))
+	queue := make([]string, len(m.confirmRows))
+	for i, row := range m.confirmRows {
+		m.voiceIDs[row.path] = row.voiceID
+		queue[i] = row.path
+		if row.voiceID != row.proposed {
+			intro = append(intro, fmt.Sprintf("%s 使用手动指定的 Voice ID：%s", filepath.Base(row.path), row.voiceID))
+		}
+	}
+	m.errorMsg = ""
+
+	m.demoDir = ""
+	if m.cloneOpts.Text != "" {
+		m.demoDir = filepath.Join(m.paths.DemosDir, time.Now().Format("20060102_150405"))
+	}
+	return m, m.startBatch(batchClone, queue, intro...)
+}
+
+// viewConfirmTable 渲染文件与 Voice ID 对照表，错误显示在对应行下方。
+func (m *model) viewConfirmTable() string {
+	nameWidth := lipgloss.Width("文件")
+	for _, row := range m.confirmRows {
+		nameWidth = max(nameWidth, lipgloss.Width(filepath.Base(row.path)))
+	}
+	nameWidth = min(nameWidth, 40)
+	cell := lipgloss.NewStyle().Width(nameWidth).MaxWidth(nameWidth)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %s  %s\n", helpStyle.Render(cell.Render("文件")), helpStyle.Render("Voice ID"))
+	for i, row := range m.confirmRows {
+		cursor := "  "
+		if i == m.confirmCursor {
+			cursor = selectedStyle.Render("> ")
+		}
+		id := row.voiceID
+		switch {
+		case m.voiceIDEditing && i == m.confirmCursor:
+			id = selectedStyle.Render(m.voiceIDInput.View())
+		case id == "" && m.voiceIDPlanning:
+			id = helpStyle.Render("生成中...")
+		case id == "":
+			id = helpStyle.Render("（未设置）")
+		case row.err != "":
+			id = errorStyle.Render(id)
+		case id != row.proposed:
+			id = selectedStyle.Render(id) + helpStyle.Render("（已修改）")
+		}
+		fmt.Fprintf(&b, "%s%s  %s\n", cursor, cell.Render(filepath.Base(row.path)), id)
+		if pair, ok := m.promptFor(row.path); ok {
+			fmt.Fprintf(&b, "    ↳ 提示音频：%s（%s）\n", filepath.Base(pair.AudioPath), pair.Text)
+		}
+		if row.err != "" {
+			fmt.Fprintf(&b, "    %s\n", errorStyle.Render("✗ "+row.err))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"minimax/internal/config"
+	"minimax/internal/minimax"
+	"minimax/internal/minimax/minimaxfake"
+)
+
+// press 依次把按键交给 Update。
+func press(m *model, keys ...string) {
+	for _, key := range keys {
+		m.Update(keyMsg(key))
+	}
+}
+
+// editVoiceID 在确认界面把第 row 行的 Voice ID 改为 id：移动光标、按 E 编辑、清空输入后回车。
+func editVoiceID(m *model, row int, id string) {
+	for m.confirmCursor > row {
+		press(m, "k")
+	}
+	for m.confirmCursor < row {
+		press(m, "j")
+	}
+	press(m, "e", "ctrl+u")
+	if id != "" {
+		press(m, id)
+	}
+	press(m, "enter")
+}
+
+func TestConfirmVoiceIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, f *minimaxfake.Fake, paths []string)
+		actions func(m *model)
+		// wantErrs 为各行错误提示应包含的内容，空字符串表示该行有效。
+		wantErrs []string
+		// wantIDs 为批次使用的 Voice ID，"" 表示沿用预检生成的 ID。
+		wantIDs []string
+	}{
+		{
+			name:     "generated ids start the batch",
+			actions:  func(m *model) {},
+			wantErrs: []string{"", "", ""},
+			wantIDs:  []string{"", "", ""},
+		},
+		{
+			name: "generated id already in the account is renamed",
+			setup: func(t *testing.T, f *minimaxfake.Fake, paths []string) {
+				f.AddVoice(minimax.VoiceTypeCloning, generatedVoiceID(t, paths[0]))
+			},
+			actions:  func(m *model) {},
+			wantErrs: []string{"", "", ""},
+			wantIDs:  []string{"-2", "", ""},
+		},
+		{
+			name:     "invalid id",
+			actions:  func(m *model) { editVoiceID(m, 0, "1bad-voice") },
+			wantErrs: []string{"不符合命名规则", "", ""},
+		},
+		{
+			name:     "empty id",
+			actions:  func(m *model) { editVoiceID(m, 2, "") },
+			wantErrs: []string{"", "", "Voice ID 不能为空"},
+		},
+		{
+			name:     "duplicate within the batch",
+			actions:  func(m *model) { editVoiceID(m, 2, m.confirmRows[0].voiceID) },
+			wantErrs: []string{"", "", "与第 1 行重复"},
+		},
+		{
+			name: "taken by an existing voice",
+			setup: func(t *testing.T, f *minimaxfake.Fake, paths []string) {
+				f.AddVoice(minimax.VoiceTypeCloning, "voice-existing-01")
+			},
+			actions:  func(m *model) { editVoiceID(m, 1, "voice-existing-01") },
+			wantErrs: []string{"", "该 Voice ID 已存在", ""},
+		},
+		{
+			name:     "manual id",
+			actions:  func(m *model) { editVoiceID(m, 1, "voice-custom-01") },
+			wantErrs: []string{"", "", ""},
+			wantIDs:  []string{"", "voice-custom-01", ""},
+		},
+		{
+			name: "r resets to the generated id",
+			actions: func(m *model) {
+				editVoiceID(m, 1, "1bad-voice")
+				press(m, "r")
+			},
+			wantErrs: []string{"", "", ""},
+			wantIDs:  []string{"", "", ""},
+		},
+		{
+			name: "esc discards the edit",
+			actions: func(m *model) {
+				press(m, "j", "e", "ctrl+u", "1bad-voice", "esc")
+			},
+			wantErrs: []string{"", "", ""},
+			wantIDs:  []string{"", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, fake, dir := newTestModel(t, config.Clone{})
+			paths := writeSamples(t, dir, 3)
+			if tt.setup != nil {
+				tt.setup(t, fake, paths)
+			}
+			openConfirm(t, m, paths)
+			proposed := make([]string, len(m.confirmRows))
+			for i, row := range m.confirmRows {
+				proposed[i] = row.proposed
+			}
+
+			tt.actions(m)
+			invalid := 0
+			for i, row := range m.confirmRows {
+				if tt.wantErrs[i] == "" && row.err != "" || !strings.Contains(row.err, tt.wantErrs[i]) {
+					t.Errorf("row %d error = %q, want %q", i, row.err, tt.wantErrs[i])
+				}
+				if row.err != "" {
+					invalid++
+				}
+			}
+
+			// 存在无效行时 Enter 不应开始批次。
+			press(m, "enter")
+			if invalid > 0 {
+				if m.state != stateConfirm || !strings.Contains(m.errorMsg, "需要修正") {
+					t.Errorf("state %d, error %q; enter should be blocked", m.state, m.errorMsg)
+				}
+				return
+			}
+			if m.state != stateCloning {
+				t.Fatalf("batch did not start: %q", m.errorMsg)
+			}
+			m.batchCancel()
+			for i, path := range paths {
+				want := tt.wantIDs[i]
+				switch {
+				case want == "":
+					want = proposed[i]
+				case strings.HasPrefix(want, "-"):
+					want = generatedVoiceID(t, path) + want
+				}
+				if got := m.voiceIDs[path]; got != want {
+					t.Errorf("row %d voice id = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfirmBlocksWhilePlanning(t *testing.T) {
+	m, _, dir := newTestModel(t, config.Clone{})
+	paths := writeSamples(t, dir, 2)
+	m.state = stateBrowser
+	for _, path := range paths {
+		m.selected[path] = true
+		m.selectedOrder = append(m.selectedOrder, path)
+	}
+	// 不执行预检命令，确认界面一直处于生成中。
+	m.Update(keyMsg("c"))
+	press(m, "e", "r", "enter")
+	if m.state != stateConfirm || m.voiceIDEditing || !strings.Contains(m.errorMsg, "仍在生成中") {
+		t.Errorf("state %d, editing %v, error %q", m.state, m.voiceIDEditing, m.errorMsg)
+	}
+}
+
+// generatedVoiceID 返回默认哈希策略为 path 生成的 Voice ID。
+func generatedVoiceID(t *testing.T, path string) string {
+	t.Helper()
+	strategy, err := voiceIDStrategy(config.VoiceID{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := strategy.VoiceID(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
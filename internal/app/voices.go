@@ -0,0 +1,216 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"minimax/internal/minimax"
+)
+
+type voicesLoadedMsg struct {
+	Voices []minimax.Voice
+	Err    error
+}
+
+type voicesDeletedMsg struct {
+	Deleted []string
+	Failed  map[string]error
+}
+
+func (m *model) openVoicesView() (tea.Model, tea.Cmd) {
+	m.state = stateVoices
+	m.remoteVoices = nil
+	m.voicesCursor = 0
+	m.voicesSelected = make(map[string]bool)
+	m.voicesConfirmDelete = false
+	m.voicesLoading = true
+	m.errorMsg = ""
+	m.infoMsg = ""
+	return m, tea.Batch(m.spinner.Tick, listVoicesCmd(m.minimax))
+}
+
+// listVoicesCmd 只列出账户自有的克隆与设计音色，系统音色不可删除故不展示。
+func listVoicesCmd(client minimax.VoiceManager) tea.Cmd {
+	return func() tea.Msg {
+		list, err := client.GetVoices(context.Background(), minimax.VoiceTypeAll)
+		if err != nil {
+			return voicesLoadedMsg{Err: err}
+		}
+		voices := make([]minimax.Voice, 0, len(list.Cloning)+len(list.Generation))
+		for _, v := range list.All() {
+			if v.Type != minimax.VoiceTypeSystem {
+				voices = append(voices, v)
+			}
+		}
+		return voicesLoadedMsg{Voices: voices}
+	}
+}
+
+func deleteVoicesCmd(client minimax.VoiceManager, voices []minimax.Voice) tea.Cmd {
+	return func() tea.Msg {
+		result := voicesDeletedMsg{Failed: make(map[string]error)}
+		for _, v := range voices {
+			if err := client.DeleteVoice(context.Background(), v.Type, v.VoiceID); err != nil {
+				result.Failed[v.VoiceID] = err
+				continue
+			}
+			result.Deleted = append(result.Deleted, v.VoiceID)
+		}
+		return result
+	}
+}
+
+func (m *model) handleVoicesLoaded(msg voicesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.voicesLoading = false
+	if msg.Err != nil {
+		m.logger.Error().Err(msg.Err).Msg("list voices failed")
+		m.errorMsg = fmt.Sprintf("获取音色列表失败：%v", msg.Err)
+		return m, nil
+	}
+	m.remoteVoices = msg.Voices
+	if m.voicesCursor >= len(m.remoteVoices) {
+		m.voicesCursor = max(len(m.remoteVoices)-1, 0)
+	}
+	return m, nil
+}
+
+func (m *model) handleVoicesDeleted(msg voicesDeletedMsg) (tea.Model, tea.Cmd) {
+	for _, id := range msg.Deleted {
+		m.logger.Info().Str("voice_id", id).Msg("voice deleted")
+		delete(m.voicesSelected, id)
+	}
+	for id, err := range msg.Failed {
+		m.logger.Error().Err(err).Str("voice_id", id).Msg("delete voice failed")
+	}
+
+	m.infoMsg = fmt.Sprintf("已删除 %d 个音色", len(msg.Deleted))
+	m.errorMsg = ""
+	if len(msg.Failed) > 0 {
+		ids := make([]string, 0, len(msg.Failed))
+		for id := range msg.Failed {
+			ids = append(ids, id)
+		}
+		m.errorMsg = fmt.Sprintf("%d 个音色删除失败：%s（详见日志）", len(msg.Failed), strings.Join(ids, ", "))
+	}
+	m.voicesLoading = true
+	return m, tea.Batch(m.spinner.Tick, listVoicesCmd(m.minimax))
+}
+
+func (m *model) selectedVoices() []minimax.Voice {
+	voices := make([]minimax.Voice, 0, len(m.voicesSelected))
+	for _, v := range m.remoteVoices {
+		if m.voicesSelected[v.VoiceID] {
+			voices = append(voices, v)
+		}
+	}
+	return voices
+}
+
+func (m *model) updateVoicesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.voicesConfirmDelete {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "y", "enter":
+			m.voicesConfirmDelete = false
+			m.voicesLoading = true
+			return m, tea.Batch(m.spinner.Tick, deleteVoicesCmd(m.minimax, m.selectedVoices()))
+		case "n", "esc":
+			m.voicesConfirmDelete = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.state = stateBrowser
+		m.errorMsg = ""
+		m.infoMsg = ""
+		return m, m.loadDirectoryCmd(m.currentDirOrRoot())
+	case "up", "k":
+		if m.voicesCursor > 0 {
+			m.voicesCursor--
+		}
+	case "down", "j":
+		if m.voicesCursor < len(m.remoteVoices)-1 {
+			m.voicesCursor++
+		}
+	case " ", "x":
+		if m.voicesCursor < len(m.remoteVoices) {
+			id := m.remoteVoices[m.voicesCursor].VoiceID
+			if m.voicesSelected[id] {
+				delete(m.voicesSelected, id)
+			} else {
+				m.voicesSelected[id] = true
+			}
+		}
+	case "r":
+		if !m.voicesLoading {
+			m.voicesLoading = true
+			return m, tea.Batch(m.spinner.Tick, listVoicesCmd(m.minimax))
+		}
+	case "d":
+		if m.voicesLoading {
+			return m, nil
+		}
+		if len(m.voicesSelected) == 0 {
+			m.errorMsg = "请先勾选要删除的音色"
+			return m, nil
+		}
+		m.errorMsg = ""
+		m.voicesConfirmDelete = true
+	}
+	return m, nil
+}
+
+func (m *model) viewVoices() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", titleStyle.Render("MiniMax 音色库"))
+
+	if m.voicesConfirmDelete {
+		fmt.Fprintf(&b, "%s\n\n", confirmStyle.Render("确认删除以下音色？删除后不可恢复"))
+		for _, v := range m.selectedVoices() {
+			fmt.Fprintf(&b, "• %s\n", v.VoiceID)
+		}
+		fmt.Fprintf(&b, "\n%s", helpStyle.Render("按 Y/Enter 确认删除 · N/Esc 取消"))
+		return borderStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	if m.voicesLoading {
+		fmt.Fprintf(&b, "%s 正在加载...\n\n", m.spinner.View())
+	}
+
+	if len(m.remoteVoices) == 0 && !m.voicesLoading {
+		fmt.Fprintf(&b, "%s\n", helpStyle.Render("账户下暂无克隆或设计的音色"))
+	} else if len(m.remoteVoices) > 0 {
+		fmt.Fprintf(&b, "      %-40s %-16s %s\n", "Voice ID", "类型", "创建时间")
+		for i, v := range m.remoteVoices {
+			cursor := "  "
+			if i == m.voicesCursor {
+				cursor = "> "
+			}
+			mark := "[ ]"
+			if m.voicesSelected[v.VoiceID] {
+				mark = "[x]"
+			}
+			fmt.Fprintf(&b, "%s%s %-40s %-16s %s\n", cursor, mark, v.VoiceID, v.Type, v.CreatedTime)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render(fmt.Sprintf("已选 %d 个 · ↑/↓ 选择 · 空格/X 勾选 · D 删除所选 · R 刷新 · Esc/Q 返回", len(m.voicesSelected))))
+
+	status := ""
+	if m.infoMsg != "" {
+		status = statusStyle.Render(m.infoMsg)
+	}
+	if m.errorMsg != "" {
+		status = errorStyle.Render(m.errorMsg)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, borderStyle.Width(m.width-4).Render(b.String()), status)
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+)
+
+// maxConcurrency 为同时处理的文件数上限。实际发出的请求仍受客户端限速约束，
+// 更高的并发只会让更多文件排队等待令牌。
+const maxConcurrency = 8
+
+// workerSlot 为一个并发槽位，path 为空表示空闲。resumeAt 非零时槽位已为 path 预留，
+// 正处于限流冷却中，尚未开始处理。
+type workerSlot struct {
+	path     string
+	started  time.Time
+	resumeAt time.Time
+}
+
+// workerStartMsg 在限流冷却结束后开始处理预留给槽位的文件。
+type workerStartMsg struct {
+	Path string
+}
+
+// concurrencyFromConfig 将配置的并发数限制在 1~maxConcurrency，未设置时逐个处理。
+func concurrencyFromConfig(c config.Clone) int {
+	return max(1, min(c.Concurrency, maxConcurrency))
+}
+
+// busyWorkers 返回正在处理文件的槽位数。
+func (m *model) busyWorkers() int {
+	busy := 0
+	for _, w := range m.workers {
+		if w.path != "" {
+			busy++
+		}
+	}
+	return busy
+}
+
+// releaseWorker 释放处理 path 的槽位，返回槽位序号；找不到时返回 -1。
+func (m *model) releaseWorker(path string) int {
+	for i, w := range m.workers {
+		if w.path == path {
+			m.workers[i] = workerSlot{}
+			return i
+		}
+	}
+	return -1
+}
+
+// reserveWorker 将队列中的下一个文件预留给槽位 slot，等待 d 后再开始处理。
+// 预留的槽位计为忙碌，批次不会在冷却期间提前结束；批次被取消时立即开始，由处理命令按已取消记录。
+func (m *model) reserveWorker(slot int, d time.Duration) tea.Cmd {
+	path := m.cloneQueue[m.cloneIndex]
+	m.cloneIndex++
+	m.workers[slot] = workerSlot{path: path, resumeAt: time.Now().Add(d)}
+	return delayCmd(m.batchCtx, d, func() tea.Msg {
+		return workerStartMsg{Path: path}
+	})
+}
+
+func (m *model) handleWorkerStart(msg workerStartMsg) (tea.Model, tea.Cmd) {
+	for i, w := range m.workers {
+		if w.path == msg.Path && !w.resumeAt.IsZero() {
+			m.workers[i] = workerSlot{path: msg.Path, started: time.Now()}
+			return m, m.fileCmd(msg.Path)
+		}
+	}
+	return m, nil
+}
+
+// sortResults 按文件在队列中的顺序排列结果，使 CSV 与并发完成的先后无关。
+// 队列之外的记录（如凭证失效后跳过的文件）保持原有相对顺序排在最后。
+func (m *model) sortResults() {
+	order := make(map[string]int, len(m.cloneQueue))
+	for i, path := range m.cloneQueue {
+		if _, ok := order[path]; !ok {
+			order[path] = i
+		}
+	}
+	rank := func(rec exporter.Record) int {
+		if i, ok := order[rec.FilePath]; ok {
+			return i
+		}
+		return len(m.cloneQueue)
+	}
+	sort.SliceStable(m.results, func(i, j int) bool {
+		return rank(m.results[i]) < rank(m.results[j])
+	})
+}
+
+// viewWorkers 在并发执行时逐行列出各槽位当前处理的文件与所处阶段。
+func (m *model) viewWorkers() string {
+	if len(m.workers) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	for i, w := range m.workers {
+		if w.path == "" {
+			fmt.Fprintf(&b, "工作线程 %d：空闲\n", i+1)
+			continue
+		}
+		if !w.resumeAt.IsZero() {
+			fmt.Fprintf(&b, "工作线程 %d：%s · 限流冷却中，%s 后开始\n",
+				i+1, filepath.Base(w.path), max(0, time.Until(w.resumeAt)).Round(time.Second))
+			continue
+		}
+		fmt.Fprintf(&b, "工作线程 %d：%s · %s · 已用时 %s\n",
+			i+1, filepath.Base(w.path), m.workerStage(w.path), time.Since(w.started).Round(time.Second))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m *model) workerStage(path string) string {
+	if p, ok := m.uploads[path]; ok {
+		if p.total > 0 && p.sent >= p.total {
+			return "等待服务端处理"
+		}
+		if p.total > 0 {
+			return fmt.Sprintf("上传中 %.0f%%", float64(p.sent)/float64(p.total)*100)
+		}
+		return "上传中"
+	}
+	if task, ok := m.taskStatuses[path]; ok {
+		return fmt.Sprintf("任务 %d %s", task.TaskID, task.Status)
+	}
+	if m.batchKind == batchLongTTS {
+		return "合成中"
+	}
+	return "处理中"
+}
@@ -0,0 +1,317 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"minimax/internal/config"
+	"minimax/internal/exporter"
+	"minimax/internal/minimax"
+	"minimax/internal/minimax/minimaxfake"
+)
+
+// stepFor 构造 path 处理完成的消息，status 为空表示成功。
+func stepFor(path, status string, err error) cloneStepMsg {
+	if status == "" {
+		status = exporter.StatusSuccess
+	}
+	rec := exporter.Record{FilePath: path, Status: status, ErrorCode: minimax.ErrorCode(err), UpdatedAt: time.Now()}
+	return cloneStepMsg{Path: path, Err: err, Timestamp: time.Now(), Record: &rec}
+}
+
+func workerPaths(m *model) []string {
+	paths := make([]string, len(m.workers))
+	for i, w := range m.workers {
+		paths[i] = w.path
+	}
+	return paths
+}
+
+func TestWorkerPoolCompletionOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		// done 为各文件完成的先后（队列下标），outcomes 为对应的状态与错误。
+		done     []int
+		outcomes map[int]cloneStepMsg
+		want     cloneFinishedMsg
+	}{
+		{
+			name: "in order",
+			done: []int{0, 1, 2, 3, 4},
+			want: cloneFinishedMsg{Success: 5},
+		},
+		{
+			name: "reverse within the pool",
+			done: []int{2, 1, 3, 0, 4},
+			outcomes: map[int]cloneStepMsg{
+				1: stepFor("", exporter.StatusFailed, minimax.ErrInvalidAudio),
+				3: stepFor("", exporter.StatusFlagged, nil),
+			},
+			want: cloneFinishedMsg{Success: 3, Failed: 1, Flagged: 1},
+		},
+		{
+			name: "skipped and failed out of order",
+			done: []int{1, 3, 2, 4, 0},
+			outcomes: map[int]cloneStepMsg{
+				0: stepFor("", exporter.StatusSkipped, minimax.ErrDuplicateVoiceID),
+				4: stepFor("", exporter.StatusFailed, minimax.ErrServer),
+			},
+			want: cloneFinishedMsg{Success: 3, Failed: 1, Skipped: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _, dir := newTestModel(t, config.Clone{Concurrency: 3})
+			paths := writeSamples(t, dir, 5)
+			m.startBatch(batchClone, paths)
+
+			if got := workerPaths(m); got[0] != paths[0] || got[1] != paths[1] || got[2] != paths[2] || m.busyWorkers() != 3 || m.cloneIndex != 3 {
+				t.Fatalf("initial slots %v, index %d", got, m.cloneIndex)
+			}
+
+			var last tea.Cmd
+			for n, i := range tt.done {
+				msg, ok := tt.outcomes[i]
+				if !ok {
+					msg = stepFor(paths[i], "", nil)
+				}
+				msg.Path, msg.Record.FilePath = paths[i], paths[i]
+
+				before := workerPaths(m)
+				slot := -1
+				for s, p := range before {
+					if p == paths[i] {
+						slot = s
+					}
+				}
+				if slot < 0 {
+					t.Fatalf("step %d: %s is not running (slots %v)", n, filepath.Base(paths[i]), before)
+				}
+
+				_, last = m.handleCloneStep(msg)
+				after := workerPaths(m)
+				// 完成的槽位领取队列中的下一个文件，其余槽位不受影响。
+				for s := range after {
+					switch {
+					case s != slot && after[s] != before[s]:
+						t.Errorf("step %d: slot %d changed from %s to %s", n, s, filepath.Base(before[s]), filepath.Base(after[s]))
+					case s == slot && after[s] == paths[i]:
+						t.Errorf("step %d: slot %d was not released", n, s)
+					}
+				}
+				remaining := len(paths) - (n + 1)
+				if m.busyWorkers() != min(3, remaining) {
+					t.Errorf("step %d: %d busy workers, want %d", n, m.busyWorkers(), min(3, remaining))
+				}
+				if after[slot] != "" && last == nil {
+					t.Errorf("step %d: refilled slot without a command", n)
+				}
+			}
+
+			if m.releaseWorker(paths[0]) != -1 {
+				t.Error("releaseWorker found a finished file")
+			}
+			if last == nil {
+				t.Fatal("last step did not finish the batch")
+			}
+			finished, ok := last().(cloneFinishedMsg)
+			if !ok || finished != tt.want {
+				t.Fatalf("finished = %+v, want %+v", finished, tt.want)
+			}
+
+			m.handleCloneFinished(finished)
+			records := exportedRecords(t, m)
+			if len(records) != len(paths) {
+				t.Fatalf("exported %d records", len(records))
+			}
+			for i, rec := range records {
+				if rec.FilePath != paths[i] {
+					t.Errorf("row %d is %s, want %s", i, filepath.Base(rec.FilePath), filepath.Base(paths[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestWorkerPoolWaitsForBusySlots(t *testing.T) {
+	m, _, dir := newTestModel(t, config.Clone{Concurrency: 4})
+	paths := writeSamples(t, dir, 2)
+	m.startBatch(batchClone, paths)
+
+	// 槽位数不超过文件数。
+	if len(m.workers) != 2 {
+		t.Fatalf("%d workers for 2 files", len(m.workers))
+	}
+	if _, cmd := m.handleCloneStep(stepFor(paths[1], "", nil)); cmd != nil {
+		t.Error("batch finished while a worker was still busy")
+	}
+	_, cmd := m.handleCloneStep(stepFor(paths[0], "", nil))
+	if msg, ok := cmd().(cloneFinishedMsg); !ok || msg.Success != 2 {
+		t.Errorf("finished = %#v", msg)
+	}
+}
+
+func TestConcurrencyFromConfig(t *testing.T) {
+	for in, want := range map[int]int{-1: 1, 0: 1, 1: 1, 3: 3, maxConcurrency: maxConcurrency, 100: maxConcurrency} {
+		if got := concurrencyFromConfig(config.Clone{Concurrency: in}); got != want {
+			t.Errorf("concurrencyFromConfig(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestRateLimitDelaysOnlyItsSlot(t *testing.T) {
+	cooldown := rateLimitCooldown
+	rateLimitCooldown = 50 * time.Millisecond
+	t.Cleanup(func() { rateLimitCooldown = cooldown })
+
+	m, _, dir := newTestModel(t, config.Clone{Concurrency: 3})
+	paths := writeSamples(t, dir, 5)
+	m.startBatch(batchClone, paths)
+	started := m.workers[1].started
+
+	limited := stepFor(paths[0], exporter.StatusFailed, &minimax.APIError{Op: "clone", StatusCode: 1002})
+	_, delayed := m.handleCloneStep(limited)
+	if delayed == nil {
+		t.Fatal("no command to resume the rate-limited slot")
+	}
+	// 限流的槽位预留下一个文件但尚未开始，其余槽位不受影响。
+	if w := m.workers[0]; w.path != paths[3] || w.resumeAt.IsZero() || !w.started.IsZero() {
+		t.Errorf("rate-limited slot = %+v, want %s reserved", w, filepath.Base(paths[3]))
+	}
+	if m.workers[1].path != paths[1] || m.workers[1].started != started || m.busyWorkers() != 3 {
+		t.Errorf("other slots changed: %v", workerPaths(m))
+	}
+
+	// 其他槽位完成时立即领取下一个文件，不必等待冷却。
+	_, next := m.handleCloneStep(stepFor(paths[1], "", nil))
+	if w := m.workers[1]; next == nil || w.path != paths[4] || w.started.IsZero() || !w.resumeAt.IsZero() {
+		t.Errorf("slot 1 = %+v, want %s started immediately", w, filepath.Base(paths[4]))
+	}
+
+	begin := time.Now()
+	msg, ok := delayed().(workerStartMsg)
+	if !ok || msg.Path != paths[3] {
+		t.Fatalf("delayed command returned %#v", msg)
+	}
+	if elapsed := time.Since(begin); elapsed < rateLimitCooldown {
+		t.Errorf("slot resumed after %s, want at least %s", elapsed, rateLimitCooldown)
+	}
+	if _, cmd := m.handleWorkerStart(msg); cmd == nil || m.workers[0].started.IsZero() || !m.workers[0].resumeAt.IsZero() {
+		t.Errorf("slot 0 after cooldown = %+v", m.workers[0])
+	}
+
+	// 只有最后一个文件完成后批次才结束，预留期间的槽位计为忙碌。
+	for _, i := range []int{2, 4} {
+		if _, cmd := m.handleCloneStep(stepFor(paths[i], "", nil)); cmd != nil {
+			t.Fatalf("batch finished early after %s", filepath.Base(paths[i]))
+		}
+	}
+	_, cmd := m.handleCloneStep(stepFor(paths[3], "", nil))
+	if finished, ok := cmd().(cloneFinishedMsg); !ok || finished.Success != 4 || finished.Failed != 1 {
+		t.Errorf("finished = %#v", finished)
+	}
+}
+
+func TestRateLimitCooldownEndsOnCancel(t *testing.T) {
+	cooldown := rateLimitCooldown
+	rateLimitCooldown = time.Hour
+	t.Cleanup(func() { rateLimitCooldown = cooldown })
+
+	m, fake, dir := newTestModel(t, config.Clone{Concurrency: 2})
+	fake.FailNext(minimaxfake.OpClone, minimaxfake.StatusError("clone", 1002, "rate limit exceeded"))
+	fake.SetDelay(minimaxfake.OpUpload, 20*time.Millisecond)
+	paths := writeSamples(t, dir, 4)
+
+	m.state = stateBrowser
+	for _, path := range paths {
+		m.selected[path] = true
+		m.selectedOrder = append(m.selectedOrder, path)
+	}
+	_, cmd := m.Update(keyMsg("c"))
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		_, ok := msg.(voiceIDPlanMsg)
+		return ok
+	})
+	_, cmd = m.Update(keyMsg("enter"))
+	var finished cloneFinishedMsg
+	drive(t, m, cmd, func(msg tea.Msg) bool {
+		// 首个限流结果返回后取消批次，处于冷却中的槽位应立即结束。
+		if step, ok := msg.(cloneStepMsg); ok && minimax.ErrorCode(step.Err) == "rate_limited" {
+			m.cancelBatch()
+		}
+		f, ok := msg.(cloneFinishedMsg)
+		finished = f
+		return ok
+	})
+
+	if !finished.Aborted || finished.Failed != 1 || finished.Success+finished.Cancelled != 3 {
+		t.Errorf("finished = %+v", finished)
+	}
+	for i, rec := range exportedRecords(t, m) {
+		if rec.FilePath != paths[i] {
+			t.Errorf("row %d is %s", i, filepath.Base(rec.FilePath))
+		}
+	}
+}
+
+func TestConcurrentCloneBatchWithFake(t *testing.T) {
+	cooldown := rateLimitCooldown
+	rateLimitCooldown = 10 * time.Millisecond
+	t.Cleanup(func() { rateLimitCooldown = cooldown })
+
+	tests := []struct {
+		name  string
+		setup func(f *minimaxfake.Fake)
+		want  cloneFinishedMsg
+	}{
+		{
+			name: "all succeed",
+			want: cloneFinishedMsg{Success: 6},
+		},
+		{
+			name: "mixed outcomes",
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpUpload, nil, minimaxfake.StatusError("upload", 2037, "invalid audio"))
+				f.FlagNext(0, 0, 4)
+			},
+			want: cloneFinishedMsg{Success: 4, Failed: 1, Flagged: 1},
+		},
+		{
+			name: "rate limited",
+			setup: func(f *minimaxfake.Fake) {
+				f.FailNext(minimaxfake.OpClone, minimaxfake.StatusError("clone", 1002, "rate limit exceeded"))
+			},
+			want: cloneFinishedMsg{Success: 5, Failed: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, fake, dir := newTestModel(t, config.Clone{Concurrency: 3})
+			fake.SetDelay(minimaxfake.OpUpload, 10*time.Millisecond)
+			if tt.setup != nil {
+				tt.setup(fake)
+			}
+			paths := writeSamples(t, dir, 6)
+
+			finished := runClone(t, m, paths)
+			if finished != tt.want {
+				t.Errorf("finished = %+v, want %+v", finished, tt.want)
+			}
+			if len(m.workers) != 3 || m.busyWorkers() != 0 {
+				t.Errorf("%d workers, %d busy", len(m.workers), m.busyWorkers())
+			}
+			records := exportedRecords(t, m)
+			if len(records) != len(paths) {
+				t.Fatalf("exported %d records", len(records))
+			}
+			for i, rec := range records {
+				if rec.FilePath != paths[i] {
+					t.Errorf("row %d is %s, want %s", i, filepath.Base(rec.FilePath), filepath.Base(paths[i]))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	file := Config{Region: "cn", BaseURL: "https://file.example.com", Trace: true}
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Config
+	}{
+		{name: "unset keeps file values", want: file},
+		{name: "empty values are ignored", env: map[string]string{EnvRegion: "", EnvBaseURL: "", EnvTrace: ""}, want: file},
+		{
+			name: "env overrides file",
+			env:  map[string]string{EnvRegion: "global", EnvBaseURL: "https://env.example.com", EnvTrace: "false"},
+			want: Config{Region: "global", BaseURL: "https://env.example.com"},
+		},
+		{name: "invalid trace is ignored", env: map[string]string{EnvTrace: "maybe"}, want: file},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvRegion, EnvBaseURL, EnvTrace} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := ApplyEnv(file); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyEnv = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	file := Config{Region: "cn", BaseURL: "https://file.example.com"}
+	tests := []struct {
+		name  string
+		env   map[string]string
+		flags Overrides
+		want  Config
+	}{
+		{name: "config file", want: file},
+		{
+			name: "env over config",
+			env:  map[string]string{EnvRegion: "global", EnvTrace: "1"},
+			want: Config{Region: "global", BaseURL: "https://file.example.com", Trace: true},
+		},
+		{
+			name:  "flag over env",
+			env:   map[string]string{EnvRegion: "global", EnvBaseURL: "https://env.example.com"},
+			flags: Overrides{Region: "cn", BaseURL: "https://flag.example.com"},
+			want:  Config{Region: "cn", BaseURL: "https://flag.example.com"},
+		},
+		{
+			name:  "unset flags keep env",
+			env:   map[string]string{EnvBaseURL: "https://env.example.com", EnvTrace: "true"},
+			flags: Overrides{Region: "global"},
+			want:  Config{Region: "global", BaseURL: "https://env.example.com", Trace: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvRegion, EnvBaseURL, EnvTrace} {
+				t.Setenv(key, tt.env[key])
+			}
+			if got := Resolve(file, tt.flags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
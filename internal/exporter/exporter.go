@@ -3,11 +3,25 @@ package exporter
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 )
 
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+	// StatusFlagged 表示请求已完成，但输入内容被 MiniMax 标记为敏感，需合规复核。
+	StatusFlagged = "flagged"
+	// StatusCancelled 表示批次被用户取消时该文件尚未完成。
+	StatusCancelled = "cancelled"
+)
+
+const exportPrefix = "minimax_voice_export_"
+
 // Record 表示一次克隆或上传尝试的结果，用于导出 CSV。
 type Record struct {
 	FilePath       string
@@ -16,6 +30,24 @@ type Record struct {
 	Status         string
 	ErrorReason    string
 	UpdatedAt      time.Time
+	UploadAttempts int
+	CloneAttempts  int
+	// ErrorCode 为机器可读的错误分类，例如 rate_limited、duplicate_voice_id。
+	ErrorCode     string
+	DemoAudioURL  string
+	DemoAudioPath string
+	// PromptFilePath 与 PromptFileID 记录随样本上传的 clone_prompt 提示音频。
+	PromptFilePath string
+	PromptFileID   string
+	// UploadDeleted 表示克隆成功后已删除 MiniMax 上的源文件。
+	UploadDeleted bool
+	// TaskID 与 OutputPath 用于长文本异步合成，记录任务编号与下载到本地的文件。
+	TaskID     string
+	OutputPath string
+	// VoicePrompt 为音色设计时使用的文字描述，克隆记录为空。
+	VoicePrompt string
+	// InputSensitiveType 为 MiniMax 返回的敏感内容类型，0 表示未标记。
+	InputSensitiveType int
 }
 
 func ToCSV(records []Record, downloadsDir string) (string, error) {
@@ -31,21 +63,67 @@ func ToCSV(records []Record, downloadsDir string) (string, error) {
 		return "", fmt.Errorf("没有可导出的记录")
 	}
 
-	filename := fmt.Sprintf("minimax_voice_export_%s.csv", time.Now().Format("20060102_150405"))
-	fullPath := filepath.Join(downloadsDir, filename)
-
-	file, err := os.Create(fullPath)
+	file, fullPath, err := createExportFile(downloadsDir, time.Now())
 	if err != nil {
-		return "", fmt.Errorf("create export file: %w", err)
+		return "", err
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	if err := writeRecords(file, records); err != nil {
+		return "", err
+	}
+	return fullPath, nil
+}
+
+// Rewrite 用 records 整体覆盖已有的导出文件 path，供同一会话内不断追加结果的场景（如多次音色设计）
+// 沿用同一个文件。先写临时文件再替换，写入失败时原文件保持不变。
+func Rewrite(path string, records []Record) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	if err := writeRecords(file, records); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("close export file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("replace export file: %w", err)
+	}
+	return nil
+}
+
+func writeRecords(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
 
-	header := []string{"file_path", "minimax_file_id", "minimax_voice_id", "status", "error_reason", "updated_at"}
+	header := []string{
+		"file_path",
+		"minimax_file_id",
+		"minimax_voice_id",
+		"status",
+		"error_reason",
+		"updated_at",
+		"upload_attempts",
+		"clone_attempts",
+		"error_code",
+		"demo_audio_url",
+		"demo_audio_path",
+		"prompt_file_path",
+		"prompt_file_id",
+		"upload_deleted",
+		"task_id",
+		"output_path",
+		"voice_prompt",
+		"input_sensitive_type",
+	}
 	if err := writer.Write(header); err != nil {
-		return "", fmt.Errorf("write header: %w", err)
+		return fmt.Errorf("write header: %w", err)
 	}
 
 	for _, rec := range records {
@@ -61,11 +139,63 @@ func ToCSV(records []Record, downloadsDir string) (string, error) {
 		} else {
 			row = append(row, "")
 		}
+		row = append(row,
+			formatCount(rec.UploadAttempts),
+			formatCount(rec.CloneAttempts),
+			rec.ErrorCode,
+			rec.DemoAudioURL,
+			rec.DemoAudioPath,
+			rec.PromptFilePath,
+			rec.PromptFileID,
+			formatBool(rec.UploadDeleted),
+			rec.TaskID,
+			rec.OutputPath,
+			rec.VoicePrompt,
+			formatCount(rec.InputSensitiveType),
+		)
 
 		if err := writer.Write(row); err != nil {
-			return "", fmt.Errorf("write row: %w", err)
+			return fmt.Errorf("write row: %w", err)
 		}
 	}
 
-	return fullPath, nil
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+	return nil
+}
+
+// createExportFile 以时间戳命名导出文件。同一秒内多次导出（如音色设计后紧接着完成批次）时
+// 追加 _2、_3 … 后缀，避免覆盖先前的结果。
+func createExportFile(dir string, now time.Time) (*os.File, string, error) {
+	base := exportPrefix + now.Format("20060102_150405")
+	for n := 1; ; n++ {
+		name := base + ".csv"
+		if n > 1 {
+			name = fmt.Sprintf("%s_%d.csv", base, n)
+		}
+		path := filepath.Join(dir, name)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			return file, path, nil
+		}
+		if !os.IsExist(err) {
+			return nil, "", fmt.Errorf("create export file: %w", err)
+		}
+	}
+}
+
+func formatCount(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func formatBool(v bool) string {
+	if !v {
+		return ""
+	}
+	return "true"
 }
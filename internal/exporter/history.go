@@ -0,0 +1,87 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LoadHistory 读取 downloadsDir 中此前导出的全部 CSV，按文件从新到旧返回记录。
+// 各版本导出的列不同，因此按表头名称取值，缺失的列保持零值；无法解析的文件会被跳过。
+func LoadHistory(downloadsDir string) ([]Record, error) {
+	paths, err := filepath.Glob(filepath.Join(downloadsDir, exportPrefix+"*.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("list export files: %w", err)
+	}
+	// 文件名中的时间戳可按字典序排序。
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+	var records []Record
+	for _, path := range paths {
+		recs, err := readCSV(path)
+		if err != nil {
+			continue
+		}
+		records = append(records, recs...)
+	}
+	return records, nil
+}
+
+func readCSV(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open export file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read export file: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[name] = i
+	}
+
+	records := make([]Record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		rec := Record{
+			FilePath:       get("file_path"),
+			MinimaxFileID:  get("minimax_file_id"),
+			MinimaxVoiceID: get("minimax_voice_id"),
+			Status:         get("status"),
+			ErrorReason:    get("error_reason"),
+			ErrorCode:      get("error_code"),
+			DemoAudioURL:   get("demo_audio_url"),
+			DemoAudioPath:  get("demo_audio_path"),
+			PromptFilePath: get("prompt_file_path"),
+			PromptFileID:   get("prompt_file_id"),
+			UploadDeleted:  get("upload_deleted") == "true",
+			TaskID:         get("task_id"),
+			OutputPath:     get("output_path"),
+			VoicePrompt:    get("voice_prompt"),
+		}
+		rec.UpdatedAt, _ = time.Parse(time.RFC3339, get("updated_at"))
+		rec.UploadAttempts, _ = strconv.Atoi(get("upload_attempts"))
+		rec.CloneAttempts, _ = strconv.Atoi(get("clone_attempts"))
+		rec.InputSensitiveType, _ = strconv.Atoi(get("input_sensitive_type"))
+		records = append(records, rec)
+	}
+	return records, nil
+}
@@ -0,0 +1,161 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeExport(t *testing.T, dir, name string, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadHistoryColumnMapping(t *testing.T) {
+	updated := time.Date(2025, 3, 9, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		lines []string
+		want  Record
+	}{
+		{
+			// 最早的导出只有六列，新增的列保持零值。
+			name: "original columns",
+			lines: []string{
+				"file_path,minimax_file_id,minimax_voice_id,status,error_reason,updated_at",
+				"/a/narrator.mp3,42,voice-narrator,success,,2025-03-09T10:00:00Z",
+			},
+			want: Record{FilePath: "/a/narrator.mp3", MinimaxFileID: "42", MinimaxVoiceID: "voice-narrator", Status: StatusSuccess, UpdatedAt: updated},
+		},
+		{
+			name: "attempts and error code",
+			lines: []string{
+				"file_path,minimax_file_id,minimax_voice_id,status,error_reason,updated_at,upload_attempts,clone_attempts,error_code",
+				"/a/b.mp3,,voice-b,failed,余额不足,2025-03-09T10:00:00Z,2,,insufficient_balance",
+			},
+			want: Record{FilePath: "/a/b.mp3", MinimaxVoiceID: "voice-b", Status: StatusFailed, ErrorReason: "余额不足", UpdatedAt: updated, UploadAttempts: 2, ErrorCode: "insufficient_balance"},
+		},
+		{
+			name: "reordered and unknown columns",
+			lines: []string{
+				"status,voice_prompt,minimax_voice_id,future_column,upload_deleted,input_sensitive_type",
+				"flagged,温柔的女声,designed-01,ignored,true,3",
+			},
+			want: Record{Status: StatusFlagged, VoicePrompt: "温柔的女声", MinimaxVoiceID: "designed-01", UploadDeleted: true, InputSensitiveType: 3},
+		},
+		{
+			name: "short row",
+			lines: []string{
+				"file_path,minimax_voice_id,status,task_id,output_path",
+				"/a/c.txt,voice-c",
+			},
+			want: Record{FilePath: "/a/c.txt", MinimaxVoiceID: "voice-c"},
+		},
+		{
+			name: "unparsable numbers and time",
+			lines: []string{
+				"minimax_voice_id,updated_at,clone_attempts",
+				"voice-d,yesterday,many",
+			},
+			want: Record{MinimaxVoiceID: "voice-d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeExport(t, dir, exportPrefix+"20250309_100000.csv", tt.lines...)
+			records, err := LoadHistory(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 1 || !reflect.DeepEqual(records[0], tt.want) {
+				t.Errorf("records = %+v, want %+v", records, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadHistorySkipsMalformedFiles(t *testing.T) {
+	dir := t.TempDir()
+	header := "file_path,minimax_voice_id,status"
+	writeExport(t, dir, exportPrefix+"20250101_000000.csv", header, "/old.mp3,voice-old,success")
+	writeExport(t, dir, exportPrefix+"20250201_000000.csv", header, `/broken.mp3,"voice-broken,success`)
+	writeExport(t, dir, exportPrefix+"20250301_000000.csv", header, "/new.mp3,voice-new,success")
+	writeExport(t, dir, exportPrefix+"20250401_000000.csv")
+	// 前缀不符的 CSV 与临时文件不属于导出历史。
+	writeExport(t, dir, "other.csv", header, "/other.mp3,voice-other,success")
+	writeExport(t, dir, exportPrefix+"20250501_000000.csv.tmp", header, "/tmp.mp3,voice-tmp,success")
+	if err := os.Mkdir(filepath.Join(dir, exportPrefix+"20250601_000000.csv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := LoadHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, rec := range records {
+		ids = append(ids, rec.MinimaxVoiceID)
+	}
+	// 按文件从新到旧返回。
+	if want := []string{"voice-new", "voice-old"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("voice ids = %v, want %v", ids, want)
+	}
+}
+
+func TestLoadHistoryMissingDir(t *testing.T) {
+	records, err := LoadHistory(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || len(records) != 0 {
+		t.Errorf("LoadHistory = %v, %v", records, err)
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	full := Record{
+		FilePath:           "/a/narrator, 01.mp3",
+		MinimaxFileID:      "42",
+		MinimaxVoiceID:     "voice-narrator",
+		Status:             StatusFlagged,
+		ErrorReason:        "line one\nline two",
+		UpdatedAt:          time.Date(2025, 3, 9, 10, 0, 0, 0, time.UTC),
+		UploadAttempts:     2,
+		CloneAttempts:      3,
+		ErrorCode:          "rate_limited",
+		DemoAudioURL:       "https://example.com/demo.mp3",
+		DemoAudioPath:      "/demos/voice-narrator.mp3",
+		PromptFilePath:     "/a/narrator.prompt.wav",
+		PromptFileID:       "43",
+		UploadDeleted:      true,
+		TaskID:             "9001",
+		OutputPath:         "/tts/out.mp3",
+		VoicePrompt:        `温柔的"女声"`,
+		InputSensitiveType: 2,
+	}
+	records := []Record{full, {MinimaxVoiceID: "voice-empty", Status: StatusSuccess}}
+
+	path, err := ToCSV(records, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadHistory(dir)
+	if err != nil || !reflect.DeepEqual(got, records) {
+		t.Fatalf("LoadHistory = %+v, %v; want %+v", got, err, records)
+	}
+
+	// Rewrite 覆盖同一文件，不新增导出。
+	records = append(records, Record{MinimaxVoiceID: "voice-appended", Status: StatusFailed})
+	if err := Rewrite(path, records); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = LoadHistory(dir)
+	exports, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if !reflect.DeepEqual(got, records) || len(exports) != 1 {
+		t.Errorf("after Rewrite: %d files, records %+v", len(exports), got)
+	}
+}
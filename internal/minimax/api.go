@@ -0,0 +1,50 @@
+package minimax
+
+import (
+	"context"
+	"io"
+)
+
+// VoiceCloner 为克隆流程所需的操作：上传样本、克隆、清理上传文件与下载试听音频。
+// 界面层依赖接口而非 *Client，以便用 minimaxfake 在无网络环境下驱动完整流程。
+type VoiceCloner interface {
+	UploadFile(ctx context.Context, filePath, purpose string) (*UploadResponse, error)
+	CloneWithFileID(ctx context.Context, fileID int64, voiceID string, opts CloneOptions) (*VoiceCloneResponse, error)
+	DeleteFile(ctx context.Context, fileID int64, purpose string) error
+	Download(ctx context.Context, rawURL, destPath string) (int64, error)
+}
+
+// FileManager 管理账户下已上传的文件。
+type FileManager interface {
+	ListFiles(ctx context.Context, purpose string) ([]File, error)
+	DeleteFile(ctx context.Context, fileID int64, purpose string) error
+}
+
+// VoiceManager 查询、删除与设计音色。
+type VoiceManager interface {
+	GetVoices(ctx context.Context, voiceType string) (*VoiceList, error)
+	DeleteVoice(ctx context.Context, voiceType, voiceID string) error
+	DesignVoice(ctx context.Context, req DesignVoiceRequest) (*DesignVoiceResult, error)
+}
+
+// Synthesizer 涵盖同步、流式与异步长文本合成。
+type Synthesizer interface {
+	Synthesize(ctx context.Context, req SynthesizeRequest) (*SynthesizeResult, error)
+	SynthesizeStream(ctx context.Context, req SynthesizeRequest, w io.Writer) (*SynthesizeResult, error)
+	CreateSpeechTask(ctx context.Context, req SynthesizeRequest) (*SpeechTask, error)
+	WaitSpeechTask(ctx context.Context, taskID int64, policy PollPolicy, onStatus func(*SpeechTask)) (*SpeechTask, error)
+	DownloadFile(ctx context.Context, fileID int64, dir string) (string, *File, error)
+}
+
+// API 为界面层使用的全部 MiniMax 能力。
+type API interface {
+	VoiceCloner
+	FileManager
+	VoiceManager
+	Synthesizer
+}
+
+var (
+	_ VoiceCloner = (*Client)(nil)
+	_ API         = (*Client)(nil)
+)
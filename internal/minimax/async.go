@@ -0,0 +1,150 @@
+package minimax
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// MaxAsyncTextLength 是异步长文本合成单次请求允许的最大字符数。
+const MaxAsyncTextLength = 50000
+
+const (
+	TaskStatusProcessing = "Processing"
+	TaskStatusSuccess    = "Success"
+	TaskStatusFailed     = "Failed"
+	TaskStatusExpired    = "Expired"
+)
+
+// SpeechTask 表示一次异步长文本合成任务，FileID 在任务成功后指向生成的音频文件。
+type SpeechTask struct {
+	TaskID          int64  `json:"task_id"`
+	Status          string `json:"status"`
+	FileID          int64  `json:"file_id"`
+	UsageCharacters int64  `json:"usage_characters"`
+	BaseResp        `json:"base_resp"`
+}
+
+// PollPolicy 控制轮询异步任务状态的间隔，每次轮询后间隔按 Multiplier 增长直至 MaxInterval。
+type PollPolicy struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+}
+
+func DefaultPollPolicy() PollPolicy {
+	return PollPolicy{
+		Interval:    2 * time.Second,
+		MaxInterval: 30 * time.Second,
+		Multiplier:  1.5,
+	}
+}
+
+// CreateSpeechTask 创建异步长文本合成任务（t2a_async_v2）。
+func (c *Client) CreateSpeechTask(ctx context.Context, req SynthesizeRequest) (*SpeechTask, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if n := len([]rune(req.Text)); n > MaxAsyncTextLength {
+		return nil, fmt.Errorf("create speech task: text has %d characters, limit is %d", n, MaxAsyncTextLength)
+	}
+	endpoint := c.endpoint("/v1/t2a_async_v2", url.Values{"GroupId": {c.groupID}})
+
+	var task SpeechTask
+	if _, err := c.postJSON(ctx, "create speech task", endpoint, req.payload(), &task); err != nil {
+		return nil, err
+	}
+	if task.Status == "" {
+		task.Status = TaskStatusProcessing
+	}
+	return &task, nil
+}
+
+func (c *Client) QuerySpeechTask(ctx context.Context, taskID int64) (*SpeechTask, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	endpoint := c.endpoint("/v1/query/t2a_async_query_v2", url.Values{
+		"GroupId": {c.groupID},
+		"task_id": {strconv.FormatInt(taskID, 10)},
+	})
+
+	var task SpeechTask
+	if _, err := c.getJSON(ctx, "query speech task", endpoint, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// WaitSpeechTask 按 policy 轮询任务直至成功、失败或 ctx 结束，每次查询后调用 onStatus（可为空）。
+func (c *Client) WaitSpeechTask(ctx context.Context, taskID int64, policy PollPolicy, onStatus func(*SpeechTask)) (*SpeechTask, error) {
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = DefaultPollPolicy().Interval
+	}
+
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("wait speech task %d: %w", taskID, ctx.Err())
+		case <-timer.C:
+		}
+
+		task, err := c.QuerySpeechTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if onStatus != nil {
+			onStatus(task)
+		}
+
+		switch task.Status {
+		case TaskStatusSuccess:
+			return task, nil
+		case TaskStatusFailed, TaskStatusExpired:
+			return task, fmt.Errorf("%w: task %d %s", ErrTaskFailed, taskID, task.Status)
+		}
+
+		interval = policy.next(interval)
+	}
+}
+
+// next 返回 interval 之后的下一次轮询间隔。
+func (p PollPolicy) next(interval time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		interval = time.Duration(float64(interval) * p.Multiplier)
+	}
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
+}
+
+// DownloadFile 通过 Files API 获取文件下载地址并保存到 dir，返回本地路径与文件信息。
+func (c *Client) DownloadFile(ctx context.Context, fileID int64, dir string) (string, *File, error) {
+	file, err := c.RetrieveFile(ctx, fileID)
+	if err != nil {
+		return "", nil, err
+	}
+	if file.DownloadURL == "" {
+		return "", file, fmt.Errorf("download file %d: no download url", fileID)
+	}
+
+	name := file.Filename
+	if name == "" {
+		name = strconv.FormatInt(fileID, 10)
+	}
+	destPath := filepath.Join(dir, fmt.Sprintf("%d_%s", fileID, filepath.Base(name)))
+	if _, err := c.Download(ctx, file.DownloadURL, destPath); err != nil {
+		return "", file, err
+	}
+	return destPath, file, nil
+}
@@ -0,0 +1,247 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"minimax/internal/minimax/minimaxserver"
+	"minimax/internal/minimax/minimaxtest"
+)
+
+var fastPoll = PollPolicy{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Multiplier: 2}
+
+func asyncRequest() SynthesizeRequest {
+	return SynthesizeRequest{Text: "很长的一段文本", VoiceSetting: VoiceSetting{VoiceID: minimaxserver.SystemVoices[0]}}
+}
+
+// createTask 在替身服务上创建一个异步合成任务。
+func createTask(t *testing.T, client *Client) *SpeechTask {
+	t.Helper()
+	task, err := client.CreateSpeechTask(context.Background(), asyncRequest())
+	if err != nil {
+		t.Fatalf("CreateSpeechTask: %v", err)
+	}
+	if task.TaskID == 0 || task.Status != TaskStatusProcessing || task.UsageCharacters != int64(len([]rune(asyncRequest().Text))) {
+		t.Fatalf("created task = %+v", task)
+	}
+	return task
+}
+
+func TestWaitSpeechTaskLifecycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		polls      int
+		wantErr    bool
+		wantStatus []string
+	}{
+		{name: "processing then success", status: TaskStatusSuccess, polls: 2, wantStatus: []string{"Processing", "Processing", "Success"}},
+		{name: "failed", status: TaskStatusFailed, polls: 1, wantErr: true, wantStatus: []string{"Processing", "Failed"}},
+		{name: "expired", status: TaskStatusExpired, wantErr: true, wantStatus: []string{"Expired"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			srv.SetTaskOutcome(tt.status, tt.polls)
+			client := newTestClient(srv.URL)
+			created := createTask(t, client)
+
+			var seen []string
+			task, err := client.WaitSpeechTask(context.Background(), created.TaskID, fastPoll, func(task *SpeechTask) {
+				seen = append(seen, task.Status)
+			})
+			if len(seen) != len(tt.wantStatus) {
+				t.Fatalf("statuses = %v, want %v", seen, tt.wantStatus)
+			}
+			for i := range seen {
+				if seen[i] != tt.wantStatus[i] {
+					t.Fatalf("statuses = %v, want %v", seen, tt.wantStatus)
+				}
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrTaskFailed) || ErrorCode(err) != "task_failed" || task == nil || task.Status != tt.status {
+					t.Errorf("WaitSpeechTask = %+v, %v; want %s task error", task, err, tt.status)
+				}
+				return
+			}
+			if err != nil || task.FileID != created.FileID {
+				t.Errorf("WaitSpeechTask = %+v, %v; want file %d", task, err, created.FileID)
+			}
+		})
+	}
+}
+
+func TestPollPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PollPolicy
+		want   []time.Duration
+	}{
+		{
+			name:   "grows to the cap",
+			policy: PollPolicy{Interval: 2 * time.Second, MaxInterval: 10 * time.Second, Multiplier: 2},
+			want:   []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:   "default policy",
+			policy: DefaultPollPolicy(),
+			want:   []time.Duration{2 * time.Second, 3 * time.Second, 4500 * time.Millisecond, 6750 * time.Millisecond},
+		},
+		{
+			name:   "no multiplier keeps the interval",
+			policy: PollPolicy{Interval: time.Second, MaxInterval: 10 * time.Second},
+			want:   []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:   "no cap",
+			policy: PollPolicy{Interval: time.Second, Multiplier: 3},
+			want:   []time.Duration{time.Second, 3 * time.Second, 9 * time.Second, 27 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := tt.policy.Interval
+			for i, want := range tt.want {
+				if interval != want {
+					t.Fatalf("interval %d = %s, want %s", i+1, interval, want)
+				}
+				interval = tt.policy.next(interval)
+			}
+		})
+	}
+}
+
+func TestWaitSpeechTaskWaitsBetweenPolls(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.SetTaskOutcome(TaskStatusSuccess, 3)
+	client := newTestClient(srv.URL)
+	created := createTask(t, client)
+
+	policy := PollPolicy{Interval: 10 * time.Millisecond, MaxInterval: 25 * time.Millisecond, Multiplier: 2}
+	start := time.Now()
+	var polledAt []time.Duration
+	_, err := client.WaitSpeechTask(context.Background(), created.TaskID, policy, func(*SpeechTask) {
+		polledAt = append(polledAt, time.Since(start))
+	})
+	if err != nil {
+		t.Fatalf("WaitSpeechTask: %v", err)
+	}
+	// 间隔依次为 10ms、20ms、25ms（封顶）、25ms，计时器只会晚到，不会早到。
+	want := []time.Duration{10, 30, 55, 80}
+	if len(polledAt) != len(want) {
+		t.Fatalf("polled %d times, want %d", len(polledAt), len(want))
+	}
+	for i, at := range polledAt {
+		if at < want[i]*time.Millisecond {
+			t.Errorf("poll %d at %s, want no earlier than %dms", i+1, at, want[i])
+		}
+	}
+}
+
+func TestWaitSpeechTaskCancel(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PollPolicy
+		setup  func(srv *minimaxtest.Server)
+	}{
+		{name: "while waiting to poll", policy: PollPolicy{Interval: time.Hour}},
+		{
+			name:   "while a query is in flight",
+			policy: fastPoll,
+			setup: func(srv *minimaxtest.Server) {
+				srv.InjectFault("/v1/query/t2a_async_query_v2", minimaxserver.Fault{Latency: time.Hour})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			client := newTestClient(srv.URL)
+			created := createTask(t, client)
+			if tt.setup != nil {
+				tt.setup(srv)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			start := time.Now()
+			task, err := client.WaitSpeechTask(ctx, created.TaskID, tt.policy, nil)
+			if !errors.Is(err, context.Canceled) || task != nil {
+				t.Fatalf("WaitSpeechTask = %+v, %v; want cancelled", task, err)
+			}
+			if ErrorCode(err) != "cancelled" {
+				t.Errorf("ErrorCode = %q, want cancelled", ErrorCode(err))
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("cancel took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestDownloadFileWritesPartThenRenames(t *testing.T) {
+	srv := newFakeServer(t)
+	client := newTestClient(srv.URL)
+	created := createTask(t, client)
+	task, err := client.WaitSpeechTask(context.Background(), created.TaskID, fastPoll, nil)
+	if err != nil {
+		t.Fatalf("WaitSpeechTask: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "out")
+	// 写完最后一块时内容仍在 .part 中，目标文件尚未出现。
+	var partPath string
+	var destExisted bool
+	ctx := WithProgress(context.Background(), func(sent, total int64) {
+		if sent < total {
+			return
+		}
+		if matches, _ := filepath.Glob(filepath.Join(dir, "*.part")); len(matches) == 1 {
+			partPath = matches[0]
+			_, statErr := os.Stat(strings.TrimSuffix(partPath, ".part"))
+			destExisted = statErr == nil
+		}
+	})
+
+	path, file, err := client.DownloadFile(ctx, task.FileID, dir)
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	if partPath != path+".part" || destExisted {
+		t.Errorf("download of %s went through %q (destination existed: %v)", path, partPath, destExisted)
+	}
+	if file.FileID != task.FileID || filepath.Dir(path) != dir {
+		t.Errorf("DownloadFile = %s, %+v", path, file)
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, minimaxserver.Audio) {
+		t.Errorf("downloaded %q", data)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, "*.part")); len(leftovers) != 0 {
+		t.Errorf("partial files left behind: %v", leftovers)
+	}
+}
+
+func TestDownloadFileFailureLeavesNoFile(t *testing.T) {
+	srv := newFakeServer(t)
+	client := newTestClient(srv.URL)
+	created := createTask(t, client)
+	task, err := client.WaitSpeechTask(context.Background(), created.TaskID, fastPoll, nil)
+	if err != nil {
+		t.Fatalf("WaitSpeechTask: %v", err)
+	}
+	dir := t.TempDir()
+	srv.InjectFault("/fake/files/"+strconv.FormatInt(task.FileID, 10), minimaxserver.Fault{HTTPStatus: 502})
+	if _, _, err := client.DownloadFile(context.Background(), task.FileID, dir); err == nil {
+		t.Fatal("download should fail")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("failed download left %d files", len(entries))
+	}
+}
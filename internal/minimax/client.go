@@ -3,23 +3,37 @@ package minimax
 import (
 	"bytes"
 	"context"
-	"crypto/md5"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/rs/zerolog"
 )
 
 const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 
+const (
+	RegionChina  = "cn"
+	RegionGlobal = "global"
+
+	DefaultBaseURL = "https://api.minimaxi.com"
+)
+
+var regionBaseURLs = map[string]string{
+	RegionChina:  DefaultBaseURL,
+	RegionGlobal: "https://api.minimax.io",
+}
+
 var (
 	rng   = randSource()
 	rngMu sync.Mutex
@@ -32,31 +46,118 @@ func randSource() *rand.Rand {
 type Client struct {
 	apiKey     string
 	groupID    string
+	baseURL    string
+	retry      RetryPolicy
 	httpClient *http.Client
+	// timeouts 按接口类别作用于每次请求的 context，httpClient 本身不设超时。
+	timeouts Timeouts
+
+	limiter     *rateLimiter
+	onRateLimit RateLimitObserver
+	trace       *zerolog.Logger
+}
+
+// Option 用于在创建 Client 时覆盖默认设置。
+type Option func(*Client)
+
+// WithBaseURL 指定 API 根地址，例如国际站、企业出口网关或本地模拟服务。
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// ResolveBaseURL 根据显式地址或区域预设得出 API 根地址，显式地址优先，均为空时使用国内站。
+func ResolveBaseURL(baseURL, region string) (string, error) {
+	if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return "", fmt.Errorf("parse base url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return "", fmt.Errorf("invalid base url %q: must be an absolute http(s) url", baseURL)
+		}
+		return baseURL, nil
+	}
+
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return DefaultBaseURL, nil
+	}
+	preset, ok := regionBaseURLs[region]
+	if !ok {
+		return "", fmt.Errorf("unknown region %q (supported: %s, %s)", region, RegionChina, RegionGlobal)
+	}
+	return preset, nil
+}
+
+// sensitiveTypes 为 input_sensitive_type 的含义。
+var sensitiveTypes = map[int]string{
+	1: "严重违规",
+	2: "色情",
+	3: "广告",
+	4: "违禁",
+	5: "谩骂",
+	6: "暴恐",
+	7: "其他",
+}
+
+// SensitiveTypeText 返回 input_sensitive_type 的中文说明，未知类型返回“未知类型 N”。
+func SensitiveTypeText(t int) string {
+	if text, ok := sensitiveTypes[t]; ok {
+		return text
+	}
+	return fmt.Sprintf("未知类型 %d", t)
 }
 
 type VoiceCloneResponse struct {
 	InputSensitive     bool   `json:"input_sensitive"`
 	InputSensitiveType int    `json:"input_sensitive_type"`
 	DemoAudio          string `json:"demo_audio"`
-	BaseResp           struct {
-		StatusCode int    `json:"status_code"`
-		StatusMsg  string `json:"status_msg"`
-	} `json:"base_resp"`
+	BaseResp           `json:"base_resp"`
+	Attempts           int `json:"-"`
 }
 
 type UploadResponse struct {
-	File struct {
-		FileID    int64  `json:"file_id"`
-		Bytes     int64  `json:"bytes"`
-		CreatedAt int64  `json:"created_at"`
-		Filename  string `json:"filename"`
-		Purpose   string `json:"purpose"`
-	} `json:"file"`
-	BaseResp struct {
-		StatusCode int    `json:"status_code"`
-		StatusMsg  string `json:"status_msg"`
-	} `json:"base_resp"`
+	File     File `json:"file"`
+	BaseResp `json:"base_resp"`
+	Attempts int `json:"-"`
+}
+
+// CloneOptions 对应 voice_clone 接口的可选参数，零值表示沿用服务端默认行为。
+type CloneOptions struct {
+	NeedNoiseReduction      bool
+	NeedVolumeNormalization bool
+	// Accuracy 为文本校验准确率阈值，取值 0~1，0 表示不发送。
+	Accuracy float64
+	// Text 与 Model 用于生成试听音频，Text 为空时不生成。
+	Text  string
+	Model string
+	// ClonePrompt 非空时随请求发送，PromptAudio 需为以 PurposePromptAudio 上传的文件 ID。
+	ClonePrompt *ClonePrompt
+}
+
+// ClonePrompt 为 clone_prompt 参数：一段简短的提示音频及其对应文本，用于提升克隆相似度。
+type ClonePrompt struct {
+	PromptAudio int64  `json:"prompt_audio"`
+	PromptText  string `json:"prompt_text"`
+}
+
+const (
+	PurposeVoiceClone  = "voice_clone"
+	PurposePromptAudio = "prompt_audio"
+)
+
+const DefaultModel = "speech-02-hd"
+
+// Models 为可用于试听与合成的语音模型。
+var Models = []string{
+	"speech-02-hd",
+	"speech-02-turbo",
+	"speech-01-hd",
+	"speech-01-turbo",
 }
 
 type CloneResult struct {
@@ -65,17 +166,41 @@ type CloneResult struct {
 	StatusMsg string
 }
 
-func NewClient(apiKey, groupID string) *Client {
-	return &Client{
+func NewClient(apiKey, groupID string, opts ...Option) *Client {
+	c := &Client{
 		apiKey:     apiKey,
 		groupID:    groupID,
-		httpClient: &http.Client{Timeout: 45 * time.Second},
+		baseURL:    DefaultBaseURL,
+		retry:      DefaultRetryPolicy(),
+		httpClient: &http.Client{},
+		timeouts:   defaultTimeouts(),
+		limiter:    newRateLimiter(DefaultRateLimits(), time.Now),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	// 追踪层最后包装，确保记录的是实际使用的传输层发出的请求。
+	if c.trace != nil {
+		c.httpClient.Transport = newTraceTransport(c.httpClient.Transport, *c.trace)
+	}
+	return c
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) endpoint(path string, query url.Values) string {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
 	}
+	return u
 }
 
-func (c *Client) CloneVoice(ctx context.Context, filePath string) (*CloneResult, error) {
+func (c *Client) CloneVoice(ctx context.Context, filePath string, opts CloneOptions) (*CloneResult, error) {
 	if c.apiKey == "" || c.groupID == "" {
-		return nil, fmt.Errorf("missing MiniMax credentials")
+		return nil, ErrMissingCredentials
 	}
 
 	voiceID, err := GenerateVoiceID(filePath)
@@ -83,12 +208,12 @@ func (c *Client) CloneVoice(ctx context.Context, filePath string) (*CloneResult,
 		return nil, fmt.Errorf("generate voice id: %w", err)
 	}
 
-	uploadResp, err := c.UploadFile(ctx, filePath)
+	uploadResp, err := c.UploadFile(ctx, filePath, PurposeVoiceClone)
 	if err != nil {
 		return nil, fmt.Errorf("upload file: %w", err)
 	}
 
-	cloneResp, err := c.CloneWithFileID(ctx, uploadResp.File.FileID, voiceID)
+	cloneResp, err := c.CloneWithFileID(ctx, uploadResp.File.FileID, voiceID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("clone voice: %w", err)
 	}
@@ -100,145 +225,204 @@ func (c *Client) CloneVoice(ctx context.Context, filePath string) (*CloneResult,
 	}, nil
 }
 
-func (c *Client) UploadFile(ctx context.Context, filePath string) (*UploadResponse, error) {
+func (c *Client) UploadFile(ctx context.Context, filePath, purpose string) (*UploadResponse, error) {
 	if c.apiKey == "" {
-		return nil, fmt.Errorf("missing MiniMax API key")
+		return nil, ErrMissingCredentials
+	}
+	if purpose == "" {
+		purpose = PurposeVoiceClone
 	}
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("resolve absolute path: %w", err)
 	}
 
-	file, err := os.Open(absPath)
+	info, err := os.Stat(absPath)
 	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
+		return nil, fmt.Errorf("stat file: %w", err)
 	}
-	defer file.Close()
 
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	// 预先生成 multipart 的头部与结尾，文件内容在发送时再经 io.Pipe 流式写入，
+	// 这样无需把整个音频读入内存，同时可以给出准确的 Content-Length。
+	var envelope bytes.Buffer
+	writer := multipart.NewWriter(&envelope)
 
-	if err := writer.WriteField("purpose", "voice_clone"); err != nil {
+	if err := writer.WriteField("purpose", purpose); err != nil {
 		return nil, fmt.Errorf("write multipart field: %w", err)
 	}
 
-	part, err := writer.CreateFormFile("file", filepath.Base(absPath))
-	if err != nil {
+	if _, err := writer.CreateFormFile("file", filepath.Base(absPath)); err != nil {
 		return nil, fmt.Errorf("create form file: %w", err)
 	}
-
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("copy file data: %w", err)
-	}
+	headLen := envelope.Len()
 
 	if err := writer.Close(); err != nil {
 		return nil, fmt.Errorf("close multipart writer: %w", err)
 	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.minimaxi.com/v1/files/upload", &buf)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+	head := envelope.Bytes()[:headLen]
+	tail := envelope.Bytes()[headLen:]
+
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		file, err := os.Open(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("open file: %w", err)
+		}
+
+		content := newProgressReader(file, info.Size(), progressFrom(ctx))
+		body, bodyWriter := io.Pipe()
+		go func() {
+			defer file.Close()
+			bodyWriter.CloseWithError(writeMultipartBody(bodyWriter, head, content, tail))
+		}()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint("/v1/files/upload", nil), body)
+		if err != nil {
+			body.Close()
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		req.ContentLength = int64(len(head)) + info.Size() + int64(len(tail))
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req, nil
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute upload request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	var result UploadResponse
+	attempts, err := c.do(ctx, "upload", newRequest, &result)
 	if err != nil {
-		return nil, fmt.Errorf("read upload response: %w", err)
+		return nil, err
 	}
+	result.Attempts = attempts
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("upload failed: status %d, body: %s", resp.StatusCode, string(body))
-	}
+	return &result, nil
+}
 
-	var result UploadResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("decode upload response: %w", err)
+func writeMultipartBody(w io.Writer, head []byte, file io.Reader, tail []byte) error {
+	if _, err := w.Write(head); err != nil {
+		return err
 	}
-
-	if result.BaseResp.StatusCode != 0 {
-		return nil, fmt.Errorf("minimax upload failed: %d %s", result.BaseResp.StatusCode, result.BaseResp.StatusMsg)
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("copy file data: %w", err)
 	}
-
-	return &result, nil
+	_, err := w.Write(tail)
+	return err
 }
 
-func (c *Client) CloneWithFileID(ctx context.Context, fileID int64, voiceID string) (*VoiceCloneResponse, error) {
+func (c *Client) CloneWithFileID(ctx context.Context, fileID int64, voiceID string, opts CloneOptions) (*VoiceCloneResponse, error) {
 	if c.apiKey == "" || c.groupID == "" {
-		return nil, fmt.Errorf("missing MiniMax credentials")
+		return nil, ErrMissingCredentials
 	}
-	url := fmt.Sprintf("https://api.minimaxi.com/v1/voice_clone?GroupId=%s", c.groupID)
+	if err := ValidateVoiceID(voiceID); err != nil {
+		return nil, fmt.Errorf("clone voice: %w", err)
+	}
+	endpoint := c.endpoint("/v1/voice_clone", url.Values{"GroupId": {c.groupID}})
 
 	payload := map[string]any{
 		"file_id":  fileID,
 		"voice_id": voiceID,
 	}
+	if opts.NeedNoiseReduction {
+		payload["need_noise_reduction"] = true
+	}
+	if opts.NeedVolumeNormalization {
+		payload["need_volume_normalization"] = true
+	}
+	if opts.Accuracy > 0 {
+		payload["accuracy"] = opts.Accuracy
+	}
+	if opts.Text != "" {
+		model := opts.Model
+		if model == "" {
+			model = DefaultModel
+		}
+		payload["text"] = opts.Text
+		payload["model"] = model
+	}
+	if opts.ClonePrompt != nil {
+		payload["clone_prompt"] = opts.ClonePrompt
+	}
 
-	bodyBytes, err := json.Marshal(payload)
+	var result VoiceCloneResponse
+	attempts, err := c.postJSON(ctx, "clone", endpoint, payload, &result)
 	if err != nil {
-		return nil, fmt.Errorf("marshal payload: %w", err)
+		return nil, err
 	}
+	result.Attempts = attempts
+
+	return &result, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(bodyBytes))
+func (c *Client) postJSON(ctx context.Context, op, endpoint string, payload any, out apiResponse) (int, error) {
+	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return 0, fmt.Errorf("marshal payload: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+	return c.do(ctx, op, newRequest, out)
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute clone request: %w", err)
+func (c *Client) getJSON(ctx context.Context, op, endpoint string, out apiResponse) (int, error) {
+	newRequest := func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		return req, nil
 	}
-	defer resp.Body.Close()
+	return c.do(ctx, op, newRequest, out)
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// Download 将远程音频（如克隆返回的 demo_audio）保存到 destPath，返回写入的字节数。
+// 下载地址通常为预签名链接，因此不携带 Authorization 头。
+func (c *Client) Download(ctx context.Context, rawURL, destPath string) (int64, error) {
+	reqCtx, cancel := c.withTimeout(ctx, ClassDownload)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, rawURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("read clone response: %w", err)
+		return 0, fmt.Errorf("create request: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("clone failed: status %d, body: %s", resp.StatusCode, string(respBody))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("execute download request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	var result VoiceCloneResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("decode clone response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download failed: status %d", resp.StatusCode)
 	}
 
-	if result.BaseResp.StatusCode != 0 {
-		return nil, fmt.Errorf("minimax clone failed: %d %s", result.BaseResp.StatusCode, result.BaseResp.StatusMsg)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return 0, fmt.Errorf("ensure download directory: %w", err)
 	}
 
-	return &result, nil
-}
-
-func GenerateVoiceID(path string) (string, error) {
-	absPath, err := filepath.Abs(path)
+	tmpPath := destPath + ".part"
+	file, err := os.Create(tmpPath)
 	if err != nil {
-		return "", fmt.Errorf("resolve path for hash: %w", err)
+		return 0, fmt.Errorf("create download file: %w", err)
 	}
 
-	file, err := os.Open(absPath)
+	n, err := io.Copy(file, newProgressReader(resp.Body, resp.ContentLength, progressFrom(ctx)))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		return "", fmt.Errorf("open file for hash: %w", err)
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("write download file: %w", err)
 	}
-	defer file.Close()
 
-	hasher := md5.New()
-	if _, err := io.Copy(hasher, file); err != nil {
-		return "", fmt.Errorf("hash file: %w", err)
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("finalize download file: %w", err)
 	}
-
-	sum := hasher.Sum(nil)
-	full := hex.EncodeToString(sum)
-	return fmt.Sprintf("minimax-voice-%s", full[len(full)-6:]), nil
+	return n, nil
 }
@@ -0,0 +1,181 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeSyntheticAudio 写入 size 字节的伪音频文件，内容按块变化以便校验传输是否完整。
+func writeSyntheticAudio(t *testing.T, size int64) (string, []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "large.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	block := make([]byte, 1<<20)
+	for written, n := int64(0), 0; written < size; written += int64(n) {
+		for i := range block {
+			block[i] = byte(i + int(written>>20))
+		}
+		n = int(min(int64(len(block)), size-written))
+		if _, err := f.Write(block[:n]); err != nil {
+			t.Fatal(err)
+		}
+		hasher.Write(block[:n])
+	}
+	return path, hasher.Sum(nil)
+}
+
+func TestUploadFileStreamsLargeFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("writes a 64 MiB file")
+	}
+	const size = 64 << 20
+	path, wantSum := writeSyntheticAudio(t, size)
+
+	type received struct {
+		contentLength int64
+		bodyBytes     int64
+		purpose       string
+		filename      string
+		fileSize      int64
+		fileSum       []byte
+		err           error
+	}
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := received{contentLength: r.ContentLength}
+		counter := &countingReader{r: r.Body}
+		r.Body = io.NopCloser(counter)
+		reader, err := r.MultipartReader()
+		if err != nil {
+			rec.err = err
+			got <- rec
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rec.err = err
+				break
+			}
+			switch part.FormName() {
+			case "purpose":
+				value, _ := io.ReadAll(part)
+				rec.purpose = string(value)
+			case "file":
+				rec.filename = part.FileName()
+				hasher := sha256.New()
+				rec.fileSize, rec.err = io.Copy(hasher, part)
+				rec.fileSum = hasher.Sum(nil)
+			}
+		}
+		io.Copy(io.Discard, counter)
+		rec.bodyBytes = counter.n
+		got <- rec
+		fmt.Fprint(w, `{"file":{"file_id":7,"bytes":67108864},"base_resp":{"status_code":0,"status_msg":"success"}}`)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	var lastSent, lastTotal int64
+	ctx := WithProgress(context.Background(), func(sent, total int64) {
+		lastSent, lastTotal = sent, total
+	})
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	resp, err := client.UploadFile(ctx, path, PurposeVoiceClone)
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+
+	rec := <-got
+	if rec.err != nil {
+		t.Fatalf("server failed to parse multipart body: %v", rec.err)
+	}
+	if resp.File.FileID != 7 || resp.Attempts != 1 {
+		t.Errorf("response = %+v", resp)
+	}
+	if rec.purpose != PurposeVoiceClone || rec.filename != "large.wav" {
+		t.Errorf("purpose %q, filename %q", rec.purpose, rec.filename)
+	}
+	if rec.fileSize != size || !bytes.Equal(rec.fileSum, wantSum) {
+		t.Errorf("file part: %d bytes, sha256 match %v", rec.fileSize, bytes.Equal(rec.fileSum, wantSum))
+	}
+	if rec.contentLength <= size || rec.contentLength != rec.bodyBytes {
+		t.Errorf("Content-Length %d, body %d bytes", rec.contentLength, rec.bodyBytes)
+	}
+	if lastSent != size || lastTotal != size {
+		t.Errorf("final progress %d/%d, want %d/%d", lastSent, lastTotal, size, size)
+	}
+	// 客户端与测试服务端合计的分配量应远小于文件本身，说明文件未被整体读入内存。
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > size/4 {
+		t.Errorf("upload allocated %d bytes for a %d byte file", alloc, size)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		region  string
+		want    string
+		wantErr string
+	}{
+		{name: "default", want: DefaultBaseURL},
+		{name: "china", region: "cn", want: DefaultBaseURL},
+		{name: "global", region: " Global ", want: "https://api.minimax.io"},
+		{name: "base url wins over region", baseURL: "https://proxy.example.com/minimax/", region: "global", want: "https://proxy.example.com/minimax"},
+		{name: "plain http", baseURL: "http://127.0.0.1:8080", want: "http://127.0.0.1:8080"},
+		{name: "unknown region", region: "cn-north", wantErr: `unknown region "cn-north"`},
+		{name: "bad base url wins over valid region", baseURL: "api.minimax.io", region: "cn", wantErr: "must be an absolute http(s) url"},
+		{name: "unsupported scheme", baseURL: "ftp://api.minimax.io", wantErr: "must be an absolute http(s) url"},
+		{name: "unparsable base url", baseURL: "http://[::1", wantErr: "parse base url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveBaseURL(tt.baseURL, tt.region)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || got != "" {
+					t.Errorf("ResolveBaseURL = %q, %v; want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveBaseURL = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
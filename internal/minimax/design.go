@@ -0,0 +1,74 @@
+package minimax
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DesignVoiceRequest 描述一次音色设计请求：Prompt 为音色描述，PreviewText 为试听文本，
+// VoiceID 为空时由服务端生成。
+type DesignVoiceRequest struct {
+	Prompt      string
+	PreviewText string
+	VoiceID     string
+}
+
+type DesignVoiceResult struct {
+	VoiceID    string
+	TrialAudio []byte
+	Attempts   int
+}
+
+type voiceDesignResponse struct {
+	VoiceID    string `json:"voice_id"`
+	TrialAudio string `json:"trial_audio"`
+	BaseResp   `json:"base_resp"`
+}
+
+// DesignVoice 根据文字描述生成新音色（voice_design），返回音色 ID 与解码后的试听音频。
+// 每次成功调用都会创建并计费一个音色，请求发出后只在限流时重试；超时或 5xx 时音色可能已经生成，
+// 可通过 GetVoices 核对。
+func (c *Client) DesignVoice(ctx context.Context, req DesignVoiceRequest) (*DesignVoiceResult, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		return nil, fmt.Errorf("design voice: prompt is required")
+	}
+	if strings.TrimSpace(req.PreviewText) == "" {
+		return nil, fmt.Errorf("design voice: preview text is required")
+	}
+	if req.VoiceID != "" {
+		if err := ValidateVoiceID(req.VoiceID); err != nil {
+			return nil, fmt.Errorf("design voice: %w", err)
+		}
+	}
+	endpoint := c.endpoint("/v1/voice_design", url.Values{"GroupId": {c.groupID}})
+
+	payload := map[string]any{
+		"prompt":       req.Prompt,
+		"preview_text": req.PreviewText,
+	}
+	if req.VoiceID != "" {
+		payload["voice_id"] = req.VoiceID
+	}
+
+	var result voiceDesignResponse
+	attempts, err := c.postJSON(ctx, "voice design", endpoint, payload, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	audio, err := hex.DecodeString(result.TrialAudio)
+	if err != nil {
+		return nil, fmt.Errorf("decode trial audio: %w", err)
+	}
+	return &DesignVoiceResult{
+		VoiceID:    result.VoiceID,
+		TrialAudio: audio,
+		Attempts:   attempts,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package minimax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+var (
+	ErrMissingCredentials  = errors.New("minimax: missing credentials")
+	ErrInvalidCredentials  = errors.New("minimax: invalid credentials")
+	ErrRateLimited         = errors.New("minimax: rate limited")
+	ErrInsufficientBalance = errors.New("minimax: insufficient balance")
+	ErrInvalidAudio        = errors.New("minimax: invalid audio")
+	ErrDuplicateVoiceID    = errors.New("minimax: duplicate voice id")
+	ErrInvalidVoiceID      = errors.New("minimax: invalid voice id")
+	ErrSensitiveContent    = errors.New("minimax: sensitive content")
+	ErrInvalidParams       = errors.New("minimax: invalid parameters")
+	ErrPermissionDenied    = errors.New("minimax: permission denied")
+	ErrServer              = errors.New("minimax: server error")
+	ErrTaskFailed          = errors.New("minimax: speech task failed")
+)
+
+// statusCodeKinds 将 base_resp.status_code 映射到对应的哨兵错误。
+var statusCodeKinds = map[int]error{
+	1000:  ErrServer,
+	1001:  ErrServer,
+	1002:  ErrRateLimited,
+	1004:  ErrInvalidCredentials,
+	1008:  ErrInsufficientBalance,
+	1013:  ErrServer,
+	1026:  ErrSensitiveContent,
+	1027:  ErrSensitiveContent,
+	1039:  ErrRateLimited,
+	1042:  ErrInvalidParams,
+	1043:  ErrInvalidAudio,
+	1044:  ErrInvalidAudio,
+	2013:  ErrInvalidParams,
+	2037:  ErrInvalidAudio,
+	2038:  ErrPermissionDenied,
+	2039:  ErrDuplicateVoiceID,
+	2042:  ErrPermissionDenied,
+	2045:  ErrRateLimited,
+	2048:  ErrInvalidAudio,
+	2049:  ErrInvalidCredentials,
+	20132: ErrInvalidParams,
+}
+
+// kindCodes 为哨兵错误对应的错误码。ErrorCode 按顺序匹配，错误链同时包含多个哨兵时取靠前的一项，
+// 保证导出的 error_code 稳定。
+var kindCodes = []struct {
+	kind error
+	code string
+}{
+	{ErrMissingCredentials, "missing_credentials"},
+	{ErrInvalidCredentials, "invalid_credentials"},
+	{ErrRateLimited, "rate_limited"},
+	{ErrInsufficientBalance, "insufficient_balance"},
+	{ErrInvalidAudio, "invalid_audio"},
+	{ErrDuplicateVoiceID, "duplicate_voice_id"},
+	{ErrInvalidVoiceID, "invalid_voice_id"},
+	{ErrSensitiveContent, "sensitive_content"},
+	{ErrInvalidParams, "invalid_params"},
+	{ErrPermissionDenied, "permission_denied"},
+	{ErrServer, "server_error"},
+	{ErrTaskFailed, "task_failed"},
+}
+
+// APIError 表示 MiniMax 返回的失败响应：HTTPStatus 非 200，或 base_resp.status_code 非 0。
+type APIError struct {
+	Op         string
+	HTTPStatus int
+	StatusCode int
+	StatusMsg  string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.HTTPStatus != 0 && e.HTTPStatus != http.StatusOK {
+		return fmt.Sprintf("%s failed: status %d, body: %s", e.Op, e.HTTPStatus, e.Body)
+	}
+	return fmt.Sprintf("minimax %s failed: %d %s", e.Op, e.StatusCode, e.StatusMsg)
+}
+
+// Kind 返回与该错误对应的哨兵错误，无法归类时返回 nil。
+func (e *APIError) Kind() error {
+	if e.HTTPStatus != 0 && e.HTTPStatus != http.StatusOK {
+		switch {
+		case e.HTTPStatus == http.StatusUnauthorized:
+			return ErrInvalidCredentials
+		case e.HTTPStatus == http.StatusForbidden:
+			return ErrPermissionDenied
+		case e.HTTPStatus == http.StatusTooManyRequests:
+			return ErrRateLimited
+		case e.HTTPStatus >= http.StatusInternalServerError:
+			return ErrServer
+		}
+		return nil
+	}
+	return statusCodeKinds[e.StatusCode]
+}
+
+func (e *APIError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// Code 返回便于机器处理的错误码，例如 rate_limited。
+func (e *APIError) Code() string {
+	if kind := e.Kind(); kind != nil {
+		for _, kc := range kindCodes {
+			if kc.kind == kind {
+				return kc.code
+			}
+		}
+	}
+	if e.HTTPStatus != 0 && e.HTTPStatus != http.StatusOK {
+		return fmt.Sprintf("http_%d", e.HTTPStatus)
+	}
+	return fmt.Sprintf("minimax_%d", e.StatusCode)
+}
+
+// Retryable 报告该错误是否属于可重试的临时性失败。
+func (e *APIError) Retryable() bool {
+	kind := e.Kind()
+	return kind == ErrRateLimited || kind == ErrServer
+}
+
+// ErrorCode 为任意错误给出机器可读的分类，用于导出与日志。
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code()
+	}
+	for _, kc := range kindCodes {
+		if errors.Is(err, kc.kind) {
+			return kc.code
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	return "local_error"
+}
@@ -0,0 +1,102 @@
+package minimax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestAPIErrorStatusCodes(t *testing.T) {
+	tests := []struct {
+		err       *APIError
+		kind      error
+		code      string
+		retryable bool
+	}{
+		{err: &APIError{StatusCode: 1000}, kind: ErrServer, code: "server_error", retryable: true},
+		{err: &APIError{StatusCode: 1001}, kind: ErrServer, code: "server_error", retryable: true},
+		{err: &APIError{StatusCode: 1002}, kind: ErrRateLimited, code: "rate_limited", retryable: true},
+		{err: &APIError{StatusCode: 1004}, kind: ErrInvalidCredentials, code: "invalid_credentials"},
+		{err: &APIError{StatusCode: 1008}, kind: ErrInsufficientBalance, code: "insufficient_balance"},
+		{err: &APIError{StatusCode: 1013}, kind: ErrServer, code: "server_error", retryable: true},
+		{err: &APIError{StatusCode: 1026}, kind: ErrSensitiveContent, code: "sensitive_content"},
+		{err: &APIError{StatusCode: 1027}, kind: ErrSensitiveContent, code: "sensitive_content"},
+		{err: &APIError{StatusCode: 1039}, kind: ErrRateLimited, code: "rate_limited", retryable: true},
+		{err: &APIError{StatusCode: 1042}, kind: ErrInvalidParams, code: "invalid_params"},
+		{err: &APIError{StatusCode: 1043}, kind: ErrInvalidAudio, code: "invalid_audio"},
+		{err: &APIError{StatusCode: 1044}, kind: ErrInvalidAudio, code: "invalid_audio"},
+		{err: &APIError{StatusCode: 2013}, kind: ErrInvalidParams, code: "invalid_params"},
+		{err: &APIError{StatusCode: 2037}, kind: ErrInvalidAudio, code: "invalid_audio"},
+		{err: &APIError{StatusCode: 2038}, kind: ErrPermissionDenied, code: "permission_denied"},
+		{err: &APIError{StatusCode: 2039}, kind: ErrDuplicateVoiceID, code: "duplicate_voice_id"},
+		{err: &APIError{StatusCode: 2042}, kind: ErrPermissionDenied, code: "permission_denied"},
+		{err: &APIError{StatusCode: 2045}, kind: ErrRateLimited, code: "rate_limited", retryable: true},
+		{err: &APIError{StatusCode: 2048}, kind: ErrInvalidAudio, code: "invalid_audio"},
+		{err: &APIError{StatusCode: 2049}, kind: ErrInvalidCredentials, code: "invalid_credentials"},
+		{err: &APIError{StatusCode: 20132}, kind: ErrInvalidParams, code: "invalid_params"},
+		{err: &APIError{StatusCode: 9999}, code: "minimax_9999"},
+		{err: &APIError{HTTPStatus: 200, StatusCode: 1002}, kind: ErrRateLimited, code: "rate_limited", retryable: true},
+		{err: &APIError{HTTPStatus: 401}, kind: ErrInvalidCredentials, code: "invalid_credentials"},
+		{err: &APIError{HTTPStatus: 403}, kind: ErrPermissionDenied, code: "permission_denied"},
+		{err: &APIError{HTTPStatus: 429}, kind: ErrRateLimited, code: "rate_limited", retryable: true},
+		{err: &APIError{HTTPStatus: 500}, kind: ErrServer, code: "server_error", retryable: true},
+		{err: &APIError{HTTPStatus: 503}, kind: ErrServer, code: "server_error", retryable: true},
+		{err: &APIError{HTTPStatus: 404}, code: "http_404"},
+		// HTTP 状态优先于 base_resp，非 200 响应不解析状态码。
+		{err: &APIError{HTTPStatus: 400, StatusCode: 1004}, code: "http_400"},
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("http%d_status%d", tt.err.HTTPStatus, tt.err.StatusCode)
+		t.Run(name, func(t *testing.T) {
+			if got := tt.err.Kind(); got != tt.kind {
+				t.Errorf("Kind() = %v, want %v", got, tt.kind)
+			}
+			if tt.kind != nil && !errors.Is(tt.err, tt.kind) {
+				t.Errorf("errors.Is(err, %v) = false", tt.kind)
+			}
+			if errors.Is(tt.err, ErrTaskFailed) {
+				t.Error("errors.Is matched an unrelated sentinel")
+			}
+			if got := tt.err.Code(); got != tt.code {
+				t.Errorf("Code() = %q, want %q", got, tt.code)
+			}
+			if got := ErrorCode(fmt.Errorf("wrapped: %w", tt.err)); got != tt.code {
+				t.Errorf("ErrorCode(wrapped) = %q, want %q", got, tt.code)
+			}
+			if got := tt.err.Retryable(); got != tt.retryable {
+				t.Errorf("Retryable() = %v, want %v", got, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "sentinel", err: ErrMissingCredentials, want: "missing_credentials"},
+		{name: "wrapped sentinel", err: fmt.Errorf("clone voice: %w", ErrInvalidVoiceID), want: "invalid_voice_id"},
+		{name: "attempt error", err: &AttemptError{Attempts: 3, Err: &APIError{HTTPStatus: 502}}, want: "server_error"},
+		{name: "multiple sentinels use the first listed", err: errors.Join(ErrServer, ErrRateLimited, ErrInvalidAudio), want: "rate_limited"},
+		{name: "cancelled", err: fmt.Errorf("upload: %w", context.Canceled), want: "cancelled"},
+		{name: "deadline", err: fmt.Errorf("upload: %w", context.DeadlineExceeded), want: "timeout"},
+		{name: "network timeout", err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}, want: "timeout"},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: "network"},
+		{name: "other", err: errors.New("disk full"), want: "local_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 多次执行以暴露依赖 map 遍历顺序的结果。
+			for i := 0; i < 50; i++ {
+				if got := ErrorCode(tt.err); got != tt.want {
+					t.Fatalf("ErrorCode = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
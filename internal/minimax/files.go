@@ -0,0 +1,85 @@
+package minimax
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type File struct {
+	FileID      int64  `json:"file_id"`
+	Bytes       int64  `json:"bytes"`
+	CreatedAt   int64  `json:"created_at"`
+	Filename    string `json:"filename"`
+	Purpose     string `json:"purpose"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+type listFilesResponse struct {
+	Files    []File `json:"files"`
+	BaseResp `json:"base_resp"`
+}
+
+type retrieveFileResponse struct {
+	File     File `json:"file"`
+	BaseResp `json:"base_resp"`
+}
+
+type deleteFileResponse struct {
+	FileID   int64 `json:"file_id"`
+	BaseResp `json:"base_resp"`
+}
+
+// ListFiles 列出账户下指定用途（如 PurposeVoiceClone）的已上传文件。
+func (c *Client) ListFiles(ctx context.Context, purpose string) ([]File, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if purpose == "" {
+		return nil, fmt.Errorf("list files: purpose is required")
+	}
+	endpoint := c.endpoint("/v1/files/list", url.Values{"GroupId": {c.groupID}, "purpose": {purpose}})
+
+	var result listFilesResponse
+	if _, err := c.getJSON(ctx, "list files", endpoint, &result); err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+func (c *Client) RetrieveFile(ctx context.Context, fileID int64) (*File, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	endpoint := c.endpoint("/v1/files/retrieve", url.Values{
+		"GroupId": {c.groupID},
+		"file_id": {strconv.FormatInt(fileID, 10)},
+	})
+
+	var result retrieveFileResponse
+	if _, err := c.getJSON(ctx, "retrieve file", endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result.File, nil
+}
+
+// DeleteFile 删除已上传的文件，purpose 需与上传时一致。
+func (c *Client) DeleteFile(ctx context.Context, fileID int64, purpose string) error {
+	if c.apiKey == "" || c.groupID == "" {
+		return ErrMissingCredentials
+	}
+	if purpose == "" {
+		purpose = PurposeVoiceClone
+	}
+	endpoint := c.endpoint("/v1/files/delete", url.Values{"GroupId": {c.groupID}})
+
+	payload := map[string]any{
+		"file_id": fileID,
+		"purpose": purpose,
+	}
+
+	var result deleteFileResponse
+	_, err := c.postJSON(ctx, "delete file", endpoint, payload, &result)
+	return err
+}
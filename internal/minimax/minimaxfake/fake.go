@@ -0,0 +1,417 @@
+// Package minimaxfake 提供 minimax.API 的内存实现，用于在无网络、无凭证的环境下
+// 驱动界面流程。可为每类操作设置延迟，并按调用顺序注入失败。
+package minimaxfake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"minimax/internal/minimax"
+)
+
+// Op 标识 Fake 上的一类操作，用于设置延迟、注入失败与检查调用记录。
+type Op string
+
+const (
+	OpUpload           Op = "upload"
+	OpClone            Op = "clone"
+	OpDeleteFile       Op = "delete_file"
+	OpDownload         Op = "download"
+	OpListFiles        Op = "list_files"
+	OpGetVoices        Op = "get_voices"
+	OpDeleteVoice      Op = "delete_voice"
+	OpDesignVoice      Op = "design_voice"
+	OpSynthesize       Op = "synthesize"
+	OpSynthesizeStream Op = "synthesize_stream"
+	OpCreateSpeechTask Op = "create_speech_task"
+	OpWaitSpeechTask   Op = "wait_speech_task"
+	OpDownloadFile     Op = "download_file"
+)
+
+// Audio 为 Fake 生成的占位音频内容。
+var Audio = []byte("minimaxfake audio\n")
+
+// Call 记录一次调用，Arg 为该操作的主要参数（文件路径、Voice ID、文件 ID 等）。
+type Call struct {
+	Op  Op
+	Arg string
+}
+
+type Fake struct {
+	mu       sync.Mutex
+	delays   map[Op]time.Duration
+	failures map[Op][]error
+	files    map[int64]minimax.File
+	voices   map[string]minimax.Voice
+	tasks    map[int64]int64
+	nextID   int64
+	calls    []Call
+	// flags 为接下来成功的克隆依次返回的 input_sensitive_type，0 表示不标记。
+	flags []int
+}
+
+var _ minimax.API = (*Fake)(nil)
+
+func New() *Fake {
+	return &Fake{
+		delays:   make(map[Op]time.Duration),
+		failures: make(map[Op][]error),
+		files:    make(map[int64]minimax.File),
+		voices:   make(map[string]minimax.Voice),
+		tasks:    make(map[int64]int64),
+		nextID:   1000,
+	}
+}
+
+// SetDelay 让 op 的每次调用先等待 d（可被 ctx 取消），用于观察进度与排队效果。
+func (f *Fake) SetDelay(op Op, d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delays[op] = d
+}
+
+// FailNext 为 op 接下来的调用依次排入结果：非 nil 的错误会被返回，nil 表示该次调用正常执行。
+func (f *Fake) FailNext(op Op, errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[op] = append(f.failures[op], errs...)
+}
+
+// FlagNext 让接下来成功的克隆依次带上敏感内容标记，types 为 input_sensitive_type，0 表示该次不标记。
+func (f *Fake) FlagNext(types ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flags = append(f.flags, types...)
+}
+
+// StatusError 构造与真实接口相同形态的 base_resp 错误，例如 StatusError("clone", 2039, "voice id exists")。
+func StatusError(op string, statusCode int, statusMsg string) error {
+	return &minimax.APIError{Op: op, StatusCode: statusCode, StatusMsg: statusMsg}
+}
+
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// AddVoice 预置一个已存在的音色，voiceType 取 minimax.VoiceType* 常量。
+func (f *Fake) AddVoice(voiceType, voiceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.voices[voiceID] = minimax.Voice{VoiceID: voiceID, Type: voiceType, CreatedTime: time.Now().Format(time.DateTime)}
+}
+
+// begin 记录调用、等待设定的延迟并取出排队的失败。与真实客户端一致，ctx 已结束时直接返回错误。
+func (f *Fake) begin(ctx context.Context, op Op, arg string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Op: op, Arg: arg})
+	delay := f.delays[op]
+	var err error
+	if queue := f.failures[op]; len(queue) > 0 {
+		err = queue[0]
+		f.failures[op] = queue[1:]
+	}
+	f.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+func (f *Fake) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *Fake) UploadFile(ctx context.Context, filePath, purpose string) (*minimax.UploadResponse, error) {
+	if err := f.begin(ctx, OpUpload, filePath); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("stat file: %w", err)
+	}
+	if progress := minimax.ContextProgress(ctx); progress != nil {
+		progress(0, info.Size())
+		progress(info.Size(), info.Size())
+	}
+	if purpose == "" {
+		purpose = minimax.PurposeVoiceClone
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file := minimax.File{
+		FileID:    f.newID(),
+		Bytes:     info.Size(),
+		CreatedAt: time.Now().Unix(),
+		Filename:  filepath.Base(filePath),
+		Purpose:   purpose,
+	}
+	f.files[file.FileID] = file
+	return &minimax.UploadResponse{File: file, Attempts: 1}, nil
+}
+
+func (f *Fake) CloneWithFileID(ctx context.Context, fileID int64, voiceID string, opts minimax.CloneOptions) (*minimax.VoiceCloneResponse, error) {
+	// 与真实客户端一致，非法 ID 在发出请求前即被拒绝。
+	if err := minimax.ValidateVoiceID(voiceID); err != nil {
+		return nil, fmt.Errorf("clone voice: %w", err)
+	}
+	if err := f.begin(ctx, OpClone, voiceID); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.files[fileID]; !ok {
+		return nil, StatusError("clone", 2013, "file not found")
+	}
+	if _, ok := f.voices[voiceID]; ok {
+		return nil, StatusError("clone", 2039, "voice id already exists")
+	}
+	f.voices[voiceID] = minimax.Voice{VoiceID: voiceID, Type: minimax.VoiceTypeCloning, CreatedTime: time.Now().Format(time.DateTime)}
+
+	resp := &minimax.VoiceCloneResponse{
+		BaseResp: minimax.BaseResp{StatusMsg: "success"},
+		Attempts: 1,
+	}
+	if opts.Text != "" {
+		resp.DemoAudio = "https://fake.minimax.local/demo/" + voiceID + ".mp3"
+	}
+	if len(f.flags) > 0 {
+		resp.InputSensitiveType = f.flags[0]
+		resp.InputSensitive = f.flags[0] != 0
+		f.flags = f.flags[1:]
+	}
+	return resp, nil
+}
+
+func (f *Fake) DeleteFile(ctx context.Context, fileID int64, purpose string) error {
+	if err := f.begin(ctx, OpDeleteFile, strconv.FormatInt(fileID, 10)); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.files[fileID]; !ok {
+		return StatusError("delete file", 2013, "file not found")
+	}
+	delete(f.files, fileID)
+	return nil
+}
+
+func (f *Fake) Download(ctx context.Context, rawURL, destPath string) (int64, error) {
+	if err := f.begin(ctx, OpDownload, rawURL); err != nil {
+		return 0, err
+	}
+	return writeAudio(ctx, destPath)
+}
+
+func writeAudio(ctx context.Context, destPath string) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return 0, fmt.Errorf("ensure download directory: %w", err)
+	}
+	if err := os.WriteFile(destPath, Audio, 0o644); err != nil {
+		return 0, fmt.Errorf("write download file: %w", err)
+	}
+	if progress := minimax.ContextProgress(ctx); progress != nil {
+		progress(int64(len(Audio)), int64(len(Audio)))
+	}
+	return int64(len(Audio)), nil
+}
+
+func (f *Fake) ListFiles(ctx context.Context, purpose string) ([]minimax.File, error) {
+	if err := f.begin(ctx, OpListFiles, purpose); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var files []minimax.File
+	for _, file := range f.files {
+		if file.Purpose == purpose {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FileID < files[j].FileID })
+	return files, nil
+}
+
+func (f *Fake) GetVoices(ctx context.Context, voiceType string) (*minimax.VoiceList, error) {
+	if err := f.begin(ctx, OpGetVoices, voiceType); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	list := &minimax.VoiceList{}
+	ids := make([]string, 0, len(f.voices))
+	for id := range f.voices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		v := f.voices[id]
+		if voiceType != "" && voiceType != minimax.VoiceTypeAll && voiceType != v.Type {
+			continue
+		}
+		switch v.Type {
+		case minimax.VoiceTypeSystem:
+			list.System = append(list.System, v)
+		case minimax.VoiceTypeGeneration:
+			list.Generation = append(list.Generation, v)
+		default:
+			list.Cloning = append(list.Cloning, v)
+		}
+	}
+	return list, nil
+}
+
+func (f *Fake) DeleteVoice(ctx context.Context, voiceType, voiceID string) error {
+	if err := f.begin(ctx, OpDeleteVoice, voiceID); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.voices[voiceID]; !ok {
+		return StatusError("delete voice", 2013, "voice not found")
+	}
+	delete(f.voices, voiceID)
+	return nil
+}
+
+func (f *Fake) DesignVoice(ctx context.Context, req minimax.DesignVoiceRequest) (*minimax.DesignVoiceResult, error) {
+	if req.VoiceID != "" {
+		if err := minimax.ValidateVoiceID(req.VoiceID); err != nil {
+			return nil, fmt.Errorf("design voice: %w", err)
+		}
+	}
+	if err := f.begin(ctx, OpDesignVoice, req.Prompt); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	voiceID := req.VoiceID
+	if voiceID == "" {
+		voiceID = "ttv-voice-" + strconv.FormatInt(f.newID(), 10)
+	}
+	if _, ok := f.voices[voiceID]; ok {
+		return nil, StatusError("voice design", 2039, "voice id already exists")
+	}
+	f.voices[voiceID] = minimax.Voice{VoiceID: voiceID, Type: minimax.VoiceTypeGeneration, CreatedTime: time.Now().Format(time.DateTime)}
+	return &minimax.DesignVoiceResult{VoiceID: voiceID, TrialAudio: Audio, Attempts: 1}, nil
+}
+
+func (f *Fake) Synthesize(ctx context.Context, req minimax.SynthesizeRequest) (*minimax.SynthesizeResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.begin(ctx, OpSynthesize, req.VoiceSetting.VoiceID); err != nil {
+		return nil, err
+	}
+	return &minimax.SynthesizeResult{Audio: Audio, Format: format(req), AudioLength: 1000}, nil
+}
+
+func (f *Fake) SynthesizeStream(ctx context.Context, req minimax.SynthesizeRequest, w io.Writer) (*minimax.SynthesizeResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.begin(ctx, OpSynthesizeStream, req.VoiceSetting.VoiceID); err != nil {
+		return nil, err
+	}
+	progress := minimax.ContextProgress(ctx)
+	var written int64
+	for i := 0; i < len(Audio); i += 4 {
+		n, err := w.Write(Audio[i:min(i+4, len(Audio))])
+		written += int64(n)
+		if err != nil {
+			return nil, fmt.Errorf("write audio chunk: %w", err)
+		}
+		if progress != nil {
+			progress(written, -1)
+		}
+	}
+	return &minimax.SynthesizeResult{Format: format(req), AudioLength: 1000}, nil
+}
+
+func (f *Fake) CreateSpeechTask(ctx context.Context, req minimax.SynthesizeRequest) (*minimax.SpeechTask, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := f.begin(ctx, OpCreateSpeechTask, req.VoiceSetting.VoiceID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	taskID := f.newID()
+	file := minimax.File{
+		FileID:    f.newID(),
+		Bytes:     int64(len(Audio)),
+		CreatedAt: time.Now().Unix(),
+		Filename:  "speech." + format(req),
+		Purpose:   "t2a_async",
+	}
+	f.files[file.FileID] = file
+	f.tasks[taskID] = file.FileID
+	return &minimax.SpeechTask{TaskID: taskID, FileID: file.FileID, Status: minimax.TaskStatusProcessing}, nil
+}
+
+// WaitSpeechTask 不按 policy 轮询，先回报一次 Processing，再在延迟后直接返回成功。
+func (f *Fake) WaitSpeechTask(ctx context.Context, taskID int64, policy minimax.PollPolicy, onStatus func(*minimax.SpeechTask)) (*minimax.SpeechTask, error) {
+	f.mu.Lock()
+	fileID, ok := f.tasks[taskID]
+	f.mu.Unlock()
+	if !ok {
+		return nil, StatusError("query speech task", 2013, "task not found")
+	}
+	if onStatus != nil {
+		onStatus(&minimax.SpeechTask{TaskID: taskID, Status: minimax.TaskStatusProcessing})
+	}
+	if err := f.begin(ctx, OpWaitSpeechTask, strconv.FormatInt(taskID, 10)); err != nil {
+		return nil, err
+	}
+	task := &minimax.SpeechTask{TaskID: taskID, FileID: fileID, Status: minimax.TaskStatusSuccess}
+	if onStatus != nil {
+		onStatus(task)
+	}
+	return task, nil
+}
+
+func (f *Fake) DownloadFile(ctx context.Context, fileID int64, dir string) (string, *minimax.File, error) {
+	if err := f.begin(ctx, OpDownloadFile, strconv.FormatInt(fileID, 10)); err != nil {
+		return "", nil, err
+	}
+	f.mu.Lock()
+	file, ok := f.files[fileID]
+	f.mu.Unlock()
+	if !ok {
+		return "", nil, StatusError("retrieve file", 2013, "file not found")
+	}
+	destPath := filepath.Join(dir, fmt.Sprintf("%d_%s", fileID, file.Filename))
+	if _, err := writeAudio(ctx, destPath); err != nil {
+		return "", &file, err
+	}
+	return destPath, &file, nil
+}
+
+func format(req minimax.SynthesizeRequest) string {
+	if req.AudioSetting.Format != "" {
+		return req.AudioSetting.Format
+	}
+	return minimax.DefaultAudioFormat
+}
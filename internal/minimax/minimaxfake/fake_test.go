@@ -0,0 +1,23 @@
+package minimaxfake
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCancelledContextFailsWithoutDelay(t *testing.T) {
+	f := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.GetVoices(ctx, "all"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetVoices error = %v, want context.Canceled", err)
+	}
+	if calls := f.Calls(); len(calls) != 0 {
+		t.Errorf("cancelled call was recorded: %v", calls)
+	}
+	if _, err := f.GetVoices(context.Background(), "all"); err != nil {
+		t.Errorf("GetVoices: %v", err)
+	}
+}
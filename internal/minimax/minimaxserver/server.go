@@ -0,0 +1,662 @@
+// Package minimaxserver 提供 MiniMax 接口替身的 http.Handler，在内存中维护文件、音色与异步任务，
+// 返回与真实接口一致的 base_resp 结构，并支持注入延迟、HTTP 5xx 与指定的 status_code。
+// 隐藏命令 `minimax fake-server` 直接挂载它供界面演示与验收；测试中请使用 minimaxtest.NewServer。
+// 本包不依赖 net/http/httptest，以免测试工具被链接进发布的二进制。
+package minimaxserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audio 为替身接口返回的占位音频内容。
+var Audio = []byte("minimaxserver audio\n")
+
+// SystemVoices 为 get_voice 始终返回的系统音色。
+var SystemVoices = []string{"male-qn-qingse", "female-shaonv"}
+
+// Fault 描述一次注入的故障。HTTPStatus 非 0 时直接返回该 HTTP 状态；
+// 否则 StatusCode 非 0 时返回 HTTP 200 与对应的 base_resp；Latency 在响应前等待。
+// Times 为生效次数，0 表示一直生效。
+// Handled 为 true 时先照常处理请求（例如已创建音色），再施加 Latency 与 HTTPStatus，
+// 用于模拟服务端已完成操作、但响应超时或出错的情况。
+type Fault struct {
+	Latency    time.Duration
+	HTTPStatus int
+	StatusCode int
+	StatusMsg  string
+	Times      int
+	Handled    bool
+}
+
+type file struct {
+	ID        int64
+	Bytes     int64
+	CreatedAt int64
+	Filename  string
+	Purpose   string
+	Content   []byte
+}
+
+// task 为异步合成任务：前 Processing 次查询返回 Processing，之后返回 Status。
+type task struct {
+	FileID     int64
+	Processing int
+	Status     string
+}
+
+type voice struct {
+	ID          string
+	Type        string
+	CreatedTime string
+}
+
+// Handler 实现替身接口，可直接挂载到任意 http.Server。
+type Handler struct {
+	mu      sync.Mutex
+	mux     *http.ServeMux
+	baseURL string
+	latency time.Duration
+	faults  map[string][]*Fault
+	files   map[int64]*file
+	voices  map[string]*voice
+	tasks   map[int64]*task
+	nextID  int64
+	// taskStatus 与 taskPolls 为新建异步任务的结果，见 SetTaskOutcome。
+	taskStatus string
+	taskPolls  int
+}
+
+func NewHandler() *Handler {
+	h := &Handler{
+		mux:    http.NewServeMux(),
+		faults: make(map[string][]*Fault),
+		files:  make(map[int64]*file),
+		voices: make(map[string]*voice),
+		tasks:  make(map[int64]*task),
+		nextID: 100000,
+
+		taskStatus: "Success",
+		taskPolls:  1,
+	}
+	h.mux.HandleFunc("POST /v1/files/upload", h.upload)
+	h.mux.HandleFunc("GET /v1/files/list", h.listFiles)
+	h.mux.HandleFunc("GET /v1/files/retrieve", h.retrieveFile)
+	h.mux.HandleFunc("POST /v1/files/delete", h.deleteFile)
+	h.mux.HandleFunc("GET /fake/files/{id}", h.fileContent)
+	h.mux.HandleFunc("POST /v1/voice_clone", h.voiceClone)
+	h.mux.HandleFunc("POST /v1/voice_design", h.voiceDesign)
+	h.mux.HandleFunc("POST /v1/get_voice", h.getVoice)
+	h.mux.HandleFunc("POST /v1/delete_voice", h.deleteVoice)
+	h.mux.HandleFunc("POST /v1/t2a_v2", h.t2a)
+	h.mux.HandleFunc("POST /v1/t2a_async_v2", h.t2aAsync)
+	h.mux.HandleFunc("GET /v1/query/t2a_async_query_v2", h.t2aAsyncQuery)
+	return h
+}
+
+// SetBaseURL 设置生成下载与试听链接时使用的根地址。
+func (h *Handler) SetBaseURL(baseURL string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetLatency 为所有请求增加固定延迟。
+func (h *Handler) SetLatency(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latency = d
+}
+
+// InjectFault 为 path（如 "/v1/voice_clone"）排入故障，path 为空时作用于所有接口。
+// 同一路径的多个故障按注入顺序依次生效。
+func (h *Handler) InjectFault(path string, f Fault) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.faults[path] = append(h.faults[path], &f)
+}
+
+// SetTaskOutcome 设置之后创建的异步合成任务的结果：前 polls 次查询返回 Processing，
+// 此后返回 status（Success、Failed 或 Expired）。默认先返回一次 Processing 再成功。
+func (h *Handler) SetTaskOutcome(status string, polls int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.taskStatus = status
+	h.taskPolls = polls
+}
+
+// AddVoice 预置一个已存在的音色，voiceType 取 voice_cloning 或 voice_generation。
+func (h *Handler) AddVoice(voiceType, voiceID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.voices[voiceID] = &voice{ID: voiceID, Type: voiceType, CreatedTime: time.Now().Format(time.DateTime)}
+}
+
+// VoiceIDs 返回当前保存的非系统音色 ID。
+func (h *Handler) VoiceIDs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := make([]string, 0, len(h.voices))
+	for id := range h.voices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fault, latency := h.takeFault(r.URL.Path)
+	if fault != nil && fault.Handled {
+		resp := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		h.serve(resp, r, nil)
+		if !sleep(r.Context(), latency) {
+			return
+		}
+		if fault.HTTPStatus != 0 {
+			http.Error(w, http.StatusText(fault.HTTPStatus), fault.HTTPStatus)
+			return
+		}
+		resp.writeTo(w)
+		return
+	}
+	if !sleep(r.Context(), latency) {
+		return
+	}
+	h.serve(w, r, fault)
+}
+
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, fault *Fault) {
+	if !strings.HasPrefix(r.URL.Path, "/fake/") {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") == "" {
+			writeStatus(w, 1004, "authorization failed")
+			return
+		}
+	}
+	if fault != nil {
+		if fault.HTTPStatus != 0 {
+			http.Error(w, http.StatusText(fault.HTTPStatus), fault.HTTPStatus)
+			return
+		}
+		if fault.StatusCode != 0 {
+			writeStatus(w, fault.StatusCode, fault.StatusMsg)
+			return
+		}
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// sleep 等待 d，请求在此期间结束时返回 false。
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// bufferedResponse 暂存处理结果，供 Handled 故障在延迟后再写出。
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
+
+// takeFault 取出 path 上（或全局）下一个生效的故障，并返回总延迟。
+func (h *Handler) takeFault(path string) (*Fault, time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	latency := h.latency
+	for _, key := range []string{path, ""} {
+		queue := h.faults[key]
+		if len(queue) == 0 {
+			continue
+		}
+		f := queue[0]
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				h.faults[key] = queue[1:]
+			}
+		}
+		return f, latency + f.Latency
+	}
+	return nil, latency
+}
+
+func (h *Handler) newID() int64 {
+	h.nextID++
+	return h.nextID
+}
+
+func baseResp(code int, msg string) map[string]any {
+	return map[string]any{"status_code": code, "status_msg": msg}
+}
+
+func writeJSON(w http.ResponseWriter, v map[string]any) {
+	if _, ok := v["base_resp"]; !ok {
+		v["base_resp"] = baseResp(0, "success")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeStatus(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, map[string]any{"base_resp": baseResp(code, msg)})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeStatus(w, 2013, fmt.Sprintf("invalid params: %v", err))
+		return false
+	}
+	return true
+}
+
+func (f *file) JSON(baseURL string, withURL bool) map[string]any {
+	v := map[string]any{
+		"file_id":    f.ID,
+		"bytes":      f.Bytes,
+		"created_at": f.CreatedAt,
+		"filename":   f.Filename,
+		"purpose":    f.Purpose,
+	}
+	if withURL {
+		v["download_url"] = fmt.Sprintf("%s/fake/files/%d", baseURL, f.ID)
+	}
+	return v
+}
+
+// storeFile 需在持有 h.mu 时调用。
+func (h *Handler) storeFile(name, purpose string, content []byte) *file {
+	f := &file{
+		ID:        h.newID(),
+		Bytes:     int64(len(content)),
+		CreatedAt: time.Now().Unix(),
+		Filename:  name,
+		Purpose:   purpose,
+		Content:   content,
+	}
+	h.files[f.ID] = f
+	return f
+}
+
+func (h *Handler) upload(w http.ResponseWriter, r *http.Request) {
+	purpose := r.FormValue("purpose")
+	src, header, err := r.FormFile("file")
+	if err != nil {
+		writeStatus(w, 2013, "invalid params: file is required")
+		return
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		writeStatus(w, 1000, "read upload failed")
+		return
+	}
+	if purpose == "" {
+		writeStatus(w, 2013, "invalid params: purpose is required")
+		return
+	}
+
+	h.mu.Lock()
+	f := h.storeFile(header.Filename, purpose, content)
+	h.mu.Unlock()
+	writeJSON(w, map[string]any{"file": f.JSON("", false)})
+}
+
+func (h *Handler) listFiles(w http.ResponseWriter, r *http.Request) {
+	purpose := r.URL.Query().Get("purpose")
+	h.mu.Lock()
+	files := make([]map[string]any, 0)
+	ids := make([]int64, 0, len(h.files))
+	for id, f := range h.files {
+		if f.Purpose == purpose {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		files = append(files, h.files[id].JSON("", false))
+	}
+	h.mu.Unlock()
+	writeJSON(w, map[string]any{"files": files})
+}
+
+func (h *Handler) retrieveFile(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(r.URL.Query().Get("file_id"), 10, 64)
+	h.mu.Lock()
+	f, ok := h.files[id]
+	baseURL := h.baseURL
+	h.mu.Unlock()
+	if !ok {
+		writeStatus(w, 2013, "file not found")
+		return
+	}
+	writeJSON(w, map[string]any{"file": f.JSON(baseURL, true)})
+}
+
+func (h *Handler) deleteFile(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FileID  int64  `json:"file_id"`
+		Purpose string `json:"purpose"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	f, ok := h.files[req.FileID]
+	if !ok || (req.Purpose != "" && f.Purpose != req.Purpose) {
+		writeStatus(w, 2013, "file not found")
+		return
+	}
+	delete(h.files, req.FileID)
+	writeJSON(w, map[string]any{"file_id": req.FileID})
+}
+
+func (h *Handler) fileContent(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	h.mu.Lock()
+	f, ok := h.files[id]
+	h.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(f.Content)))
+	w.Write(f.Content)
+}
+
+func (h *Handler) voiceClone(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FileID  int64  `json:"file_id"`
+		VoiceID string `json:"voice_id"`
+		Text    string `json:"text"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	f, ok := h.files[req.FileID]
+	if !ok || f.Purpose != "voice_clone" {
+		writeStatus(w, 2013, "invalid params: file_id not found")
+		return
+	}
+	if req.VoiceID == "" {
+		writeStatus(w, 2013, "invalid params: voice_id is required")
+		return
+	}
+	if _, exists := h.voices[req.VoiceID]; exists {
+		writeStatus(w, 2039, "voice id already exists")
+		return
+	}
+	h.voices[req.VoiceID] = &voice{ID: req.VoiceID, Type: "voice_cloning", CreatedTime: time.Now().Format(time.DateTime)}
+
+	resp := map[string]any{
+		"input_sensitive":      false,
+		"input_sensitive_type": 0,
+		"demo_audio":           "",
+	}
+	if req.Text != "" {
+		demo := h.storeFile(req.VoiceID+".mp3", "demo_audio", Audio)
+		resp["demo_audio"] = fmt.Sprintf("%s/fake/files/%d", h.baseURL, demo.ID)
+	}
+	writeJSON(w, resp)
+}
+
+func (h *Handler) voiceDesign(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Prompt      string `json:"prompt"`
+		PreviewText string `json:"preview_text"`
+		VoiceID     string `json:"voice_id"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Prompt == "" || req.PreviewText == "" {
+		writeStatus(w, 2013, "invalid params: prompt and preview_text are required")
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if req.VoiceID == "" {
+		req.VoiceID = fmt.Sprintf("ttv-voice-%d", h.newID())
+	}
+	if _, exists := h.voices[req.VoiceID]; exists {
+		writeStatus(w, 2039, "voice id already exists")
+		return
+	}
+	h.voices[req.VoiceID] = &voice{ID: req.VoiceID, Type: "voice_generation", CreatedTime: time.Now().Format(time.DateTime)}
+	writeJSON(w, map[string]any{"voice_id": req.VoiceID, "trial_audio": hex.EncodeToString(Audio)})
+}
+
+func (h *Handler) getVoice(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		VoiceType string `json:"voice_type"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	include := func(t string) bool { return req.VoiceType == "" || req.VoiceType == "all" || req.VoiceType == t }
+
+	groups := map[string][]map[string]any{
+		"system":           {},
+		"voice_cloning":    {},
+		"voice_generation": {},
+	}
+	if include("system") {
+		for _, id := range SystemVoices {
+			groups["system"] = append(groups["system"], map[string]any{"voice_id": id, "voice_name": id})
+		}
+	}
+	h.mu.Lock()
+	ids := make([]string, 0, len(h.voices))
+	for id := range h.voices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		v := h.voices[id]
+		if include(v.Type) {
+			groups[v.Type] = append(groups[v.Type], map[string]any{"voice_id": v.ID, "created_time": v.CreatedTime})
+		}
+	}
+	h.mu.Unlock()
+
+	writeJSON(w, map[string]any{
+		"system_voice":     groups["system"],
+		"voice_cloning":    groups["voice_cloning"],
+		"voice_generation": groups["voice_generation"],
+	})
+}
+
+func (h *Handler) deleteVoice(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		VoiceType string `json:"voice_type"`
+		VoiceID   string `json:"voice_id"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.voices[req.VoiceID]
+	if !ok || v.Type != req.VoiceType {
+		writeStatus(w, 2013, "voice not found")
+		return
+	}
+	delete(h.voices, req.VoiceID)
+	writeJSON(w, map[string]any{"voice_id": v.ID, "created_time": v.CreatedTime})
+}
+
+type t2aRequest struct {
+	Model        string `json:"model"`
+	Text         string `json:"text"`
+	Stream       bool   `json:"stream"`
+	VoiceSetting struct {
+		VoiceID string `json:"voice_id"`
+	} `json:"voice_setting"`
+	AudioSetting struct {
+		Format string `json:"format"`
+	} `json:"audio_setting"`
+	TimbreWeights []struct {
+		VoiceID string `json:"voice_id"`
+		Weight  int    `json:"weight"`
+	} `json:"timbre_weights"`
+}
+
+// checkVoices 校验请求引用的音色是否存在，需在持有 h.mu 时调用。
+func (h *Handler) checkVoices(req t2aRequest) error {
+	ids := []string{req.VoiceSetting.VoiceID}
+	if len(req.TimbreWeights) > 0 {
+		ids = ids[:0]
+		for _, tw := range req.TimbreWeights {
+			ids = append(ids, tw.VoiceID)
+		}
+	}
+	for _, id := range ids {
+		if _, ok := h.voices[id]; ok {
+			continue
+		}
+		found := false
+		for _, sys := range SystemVoices {
+			found = found || sys == id
+		}
+		if !found {
+			return fmt.Errorf("voice id %q not found", id)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) t2a(w http.ResponseWriter, r *http.Request) {
+	var req t2aRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	h.mu.Lock()
+	err := h.checkVoices(req)
+	h.mu.Unlock()
+	if err != nil {
+		writeStatus(w, 2013, "invalid params: "+err.Error())
+		return
+	}
+	format := req.AudioSetting.Format
+	if format == "" {
+		format = "mp3"
+	}
+	extra := map[string]any{
+		"audio_length":      1000,
+		"audio_sample_rate": 32000,
+		"audio_size":        len(Audio),
+		"audio_format":      format,
+	}
+	traceID := fmt.Sprintf("minimaxserver-%d", time.Now().UnixNano())
+
+	if !req.Stream {
+		writeJSON(w, map[string]any{
+			"data":       map[string]any{"audio": hex.EncodeToString(Audio), "status": 2},
+			"extra_info": extra,
+			"trace_id":   traceID,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher, _ := w.(http.Flusher)
+	send := func(v map[string]any) {
+		v["base_resp"] = baseResp(0, "")
+		data, _ := json.Marshal(v)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	for i := 0; i < len(Audio); i += 6 {
+		send(map[string]any{
+			"data":     map[string]any{"audio": hex.EncodeToString(Audio[i:min(i+6, len(Audio))]), "status": 1},
+			"trace_id": traceID,
+		})
+	}
+	send(map[string]any{
+		"data":       map[string]any{"audio": hex.EncodeToString(Audio), "status": 2},
+		"extra_info": extra,
+		"trace_id":   traceID,
+	})
+}
+
+// t2aAsync 立即生成音频文件，查询接口按 SetTaskOutcome 的设置返回任务状态。
+func (h *Handler) t2aAsync(w http.ResponseWriter, r *http.Request) {
+	var req t2aRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.checkVoices(req); err != nil {
+		writeStatus(w, 2013, "invalid params: "+err.Error())
+		return
+	}
+	format := req.AudioSetting.Format
+	if format == "" {
+		format = "mp3"
+	}
+	taskID := h.newID()
+	f := h.storeFile(fmt.Sprintf("%d.%s", taskID, format), "t2a_async", Audio)
+	h.tasks[taskID] = &task{FileID: f.ID, Processing: h.taskPolls, Status: h.taskStatus}
+	writeJSON(w, map[string]any{
+		"task_id":          taskID,
+		"file_id":          f.ID,
+		"usage_characters": len([]rune(req.Text)),
+	})
+}
+
+func (h *Handler) t2aAsyncQuery(w http.ResponseWriter, r *http.Request) {
+	taskID, _ := strconv.ParseInt(r.URL.Query().Get("task_id"), 10, 64)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t, ok := h.tasks[taskID]
+	if !ok {
+		writeStatus(w, 2013, "task not found")
+		return
+	}
+	if t.Processing > 0 {
+		t.Processing--
+		writeJSON(w, map[string]any{"task_id": taskID, "status": "Processing"})
+		return
+	}
+	if t.Status != "Success" {
+		writeJSON(w, map[string]any{"task_id": taskID, "status": t.Status})
+		return
+	}
+	writeJSON(w, map[string]any{"task_id": taskID, "status": "Success", "file_id": t.FileID})
+}
@@ -0,0 +1,21 @@
+// Package minimaxtest 基于 httptest 启动 minimaxserver 替身，仅供测试使用。
+package minimaxtest
+
+import (
+	"net/http/httptest"
+
+	"minimax/internal/minimax/minimaxserver"
+)
+
+// Server 为运行中的替身服务，URL 可直接传给 minimax.WithBaseURL。
+type Server struct {
+	*minimaxserver.Handler
+	*httptest.Server
+}
+
+func NewServer() *Server {
+	h := minimaxserver.NewHandler()
+	srv := httptest.NewServer(h)
+	h.SetBaseURL(srv.URL)
+	return &Server{Handler: h, Server: srv}
+}
@@ -0,0 +1,62 @@
+package minimax
+
+import (
+	"context"
+	"io"
+)
+
+// ProgressFunc 报告传输进度，total 未知时为 -1。每次重试都会从 0 重新计数。
+type ProgressFunc func(sent, total int64)
+
+type progressKey struct{}
+
+// WithProgress 返回携带进度回调的 context，类似 httptrace.WithClientTrace，
+// 使用该 context 发起的上传与下载都会通过 fn 报告已传输的字节数。
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	if fn == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func progressFrom(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return fn
+}
+
+type progressReader struct {
+	r        io.Reader
+	started  bool
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+// newProgressReader 在 fn 为空时直接返回原 reader。
+// 起始的 (0, total) 推迟到第一次 Read 时报告：请求在限速器放行、
+// 传输层开始读取请求体之前不会读取内容，排队期间不显示上传进度。
+func newProgressReader(r io.Reader, total int64, fn ProgressFunc) io.Reader {
+	if fn == nil {
+		return r
+	}
+	return &progressReader{r: r, total: total, progress: fn}
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	if !p.started {
+		p.started = true
+		p.progress(0, p.total)
+	}
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// ContextProgress 返回 ctx 中携带的进度回调，没有时返回 nil，
+// 供 Client 以外的实现（如 minimaxfake）按同样的约定报告进度。
+func ContextProgress(ctx context.Context) ProgressFunc {
+	return progressFrom(ctx)
+}
@@ -0,0 +1,151 @@
+package minimax
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EndpointClass 为限速分组，MiniMax 按接口类别分别计算每分钟请求数（RPM）。
+type EndpointClass string
+
+const (
+	ClassUpload EndpointClass = "upload"
+	ClassClone  EndpointClass = "clone"
+	ClassTTS    EndpointClass = "tts"
+	// ClassQuery 涵盖文件、音色管理与异步任务查询等其余接口。
+	ClassQuery EndpointClass = "query"
+)
+
+// endpointClasses 按请求路径归类，未列出的路径归入 ClassQuery。
+var endpointClasses = map[string]EndpointClass{
+	"/v1/files/upload": ClassUpload,
+	"/v1/voice_clone":  ClassClone,
+	"/v1/voice_design": ClassClone,
+	"/v1/t2a_v2":       ClassTTS,
+	"/v1/t2a_async_v2": ClassTTS,
+}
+
+func classify(path string) EndpointClass {
+	if class, ok := endpointClasses[path]; ok {
+		return class
+	}
+	return ClassQuery
+}
+
+// RateLimits 为各类接口的每分钟请求数上限，未设置或不大于 0 的类别不限速。
+type RateLimits map[EndpointClass]int
+
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		ClassUpload: 60,
+		ClassClone:  60,
+		ClassTTS:    60,
+		ClassQuery:  120,
+	}
+}
+
+// RateLimitObserver 在请求因限速需要等待时被调用，wait 为预计等待时长。
+type RateLimitObserver func(class EndpointClass, wait time.Duration)
+
+// WithRateLimits 替换默认的限速设置，传入空 RateLimits 即关闭客户端限速。
+func WithRateLimits(limits RateLimits) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(limits, time.Now)
+	}
+}
+
+func WithRateLimitObserver(fn RateLimitObserver) Option {
+	return func(c *Client) {
+		c.onRateLimit = fn
+	}
+}
+
+type rateLimiter struct {
+	buckets map[EndpointClass]*tokenBucket
+	// now 为令牌补充所用的时钟，测试中可替换。
+	now func() time.Time
+}
+
+func newRateLimiter(limits RateLimits, now func() time.Time) *rateLimiter {
+	l := &rateLimiter{buckets: make(map[EndpointClass]*tokenBucket), now: now}
+	for class, rpm := range limits {
+		if rpm > 0 {
+			l.buckets[class] = newTokenBucket(rpm, now())
+		}
+	}
+	return l
+}
+
+// wait 为 path 对应的类别取得一个令牌，必要时阻塞；ctx 结束时归还已预留的令牌。
+func (c *Client) wait(ctx context.Context, path string) error {
+	if c.limiter == nil {
+		return nil
+	}
+	class := classify(path)
+	bucket, ok := c.limiter.buckets[class]
+	if !ok {
+		return nil
+	}
+
+	delay := bucket.reserve(c.limiter.now())
+	if delay <= 0 {
+		return nil
+	}
+	if c.onRateLimit != nil {
+		c.onRateLimit(class, delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		bucket.cancel()
+		return fmt.Errorf("wait for %s rate limit: %w", class, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenBucket 以 rpm/60 每秒的速度补充令牌，容量允许约 5 秒的突发。
+// reserve 允许令牌数为负，表示已被排队中的请求预订，从而保证先到先得。
+type tokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	perSec   float64
+	last     time.Time
+}
+
+func newTokenBucket(rpm int, now time.Time) *tokenBucket {
+	capacity := max(1, float64(rpm)/12)
+	return &tokenBucket{
+		capacity: capacity,
+		tokens:   capacity,
+		perSec:   float64(rpm) / 60,
+		last:     now,
+	}
+}
+
+// reserve 预订一个令牌并返回需要等待的时长。
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed*b.perSec)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.perSec * float64(time.Second))
+}
+
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens = min(b.capacity, b.tokens+1)
+	b.mu.Unlock()
+}
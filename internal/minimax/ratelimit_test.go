@@ -0,0 +1,217 @@
+package minimax
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 为手动推进的时钟。
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestTokenBucketBurstAndRefill(t *testing.T) {
+	clock := newFakeClock()
+	// 60 RPM：每秒补充 1 个令牌，容量 5。
+	bucket := newTokenBucket(60, clock.Now())
+
+	for i := 0; i < 5; i++ {
+		if d := bucket.reserve(clock.Now()); d != 0 {
+			t.Fatalf("burst request %d waited %s", i+1, d)
+		}
+	}
+	// 超出容量的请求按先后排队，等待时长依次递增。
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		if d := bucket.reserve(clock.Now()); d != want {
+			t.Errorf("queued request %d waits %s, want %s", i+1, d, want)
+		}
+	}
+
+	clock.Advance(3 * time.Second)
+	if d := bucket.reserve(clock.Now()); d != time.Second {
+		t.Errorf("after 3s refill, request waits %s, want 1s", d)
+	}
+
+	// 长时间空闲后令牌不超过容量。
+	clock.Advance(time.Hour)
+	for i := 0; i < 5; i++ {
+		if d := bucket.reserve(clock.Now()); d != 0 {
+			t.Fatalf("request %d after idle waited %s", i+1, d)
+		}
+	}
+	if d := bucket.reserve(clock.Now()); d != time.Second {
+		t.Errorf("request beyond capacity waits %s, want 1s", d)
+	}
+}
+
+func TestTokenBucketMinimumCapacity(t *testing.T) {
+	clock := newFakeClock()
+	// 6 RPM 的容量按 rpm/12 计算不足 1，至少保留 1 个令牌。
+	bucket := newTokenBucket(6, clock.Now())
+	if d := bucket.reserve(clock.Now()); d != 0 {
+		t.Fatalf("first request waited %s", d)
+	}
+	if d := bucket.reserve(clock.Now()); d != 10*time.Second {
+		t.Errorf("second request waits %s, want 10s", d)
+	}
+}
+
+func TestTokenBucketCancel(t *testing.T) {
+	clock := newFakeClock()
+	bucket := newTokenBucket(60, clock.Now())
+	for i := 0; i < 5; i++ {
+		bucket.reserve(clock.Now())
+	}
+	if d := bucket.reserve(clock.Now()); d != time.Second {
+		t.Fatalf("queued request waits %s, want 1s", d)
+	}
+	// 放弃等待的请求归还预订，后来者不必为其多等。
+	bucket.cancel()
+	if d := bucket.reserve(clock.Now()); d != time.Second {
+		t.Errorf("after cancel, request waits %s, want 1s", d)
+	}
+
+	// 归还不会让令牌超过容量。
+	clock.Advance(time.Hour)
+	bucket.cancel()
+	for i := 0; i < 5; i++ {
+		bucket.reserve(clock.Now())
+	}
+	if d := bucket.reserve(clock.Now()); d != time.Second {
+		t.Errorf("cancel overfilled the bucket: request waits %s, want 1s", d)
+	}
+}
+
+func TestNewRateLimiterSkipsUnlimitedClasses(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(RateLimits{ClassUpload: 30, ClassClone: 0, ClassTTS: -1}, clock.Now)
+	if _, ok := limiter.buckets[ClassUpload]; !ok {
+		t.Error("upload class should be limited")
+	}
+	for _, class := range []EndpointClass{ClassClone, ClassTTS, ClassQuery} {
+		if _, ok := limiter.buckets[class]; ok {
+			t.Errorf("class %s should be unlimited", class)
+		}
+	}
+}
+
+func TestClientWaitObserverAndCancel(t *testing.T) {
+	clock := newFakeClock()
+	type observation struct {
+		class EndpointClass
+		wait  time.Duration
+	}
+	var observed []observation
+	client := NewClient("key", "group", WithRateLimitObserver(func(class EndpointClass, wait time.Duration) {
+		observed = append(observed, observation{class, wait})
+	}))
+	client.limiter = newRateLimiter(RateLimits{ClassClone: 60, ClassTTS: -1}, clock.Now)
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if err := client.wait(ctx, "/v1/voice_clone"); err != nil {
+			t.Fatalf("burst wait: %v", err)
+		}
+	}
+	// 不限速的类别与未列出的类别从不等待。
+	for i := 0; i < 100; i++ {
+		if err := client.wait(ctx, "/v1/t2a_v2"); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.wait(ctx, "/v1/get_voice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(observed) != 0 {
+		t.Fatalf("observer called without waiting: %v", observed)
+	}
+
+	cancelled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err := client.wait(cancelled, "/v1/voice_design")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait error = %v, want deadline exceeded", err)
+	}
+	if len(observed) != 1 || observed[0] != (observation{ClassClone, time.Second}) {
+		t.Errorf("observed %v, want one 1s wait on %s", observed, ClassClone)
+	}
+	// 被取消的等待已归还令牌，下一个请求仍只需等 1 秒。
+	if d := client.limiter.buckets[ClassClone].reserve(clock.Now()); d != time.Second {
+		t.Errorf("next reservation waits %s, want 1s", d)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for path, want := range map[string]EndpointClass{
+		"/v1/files/upload": ClassUpload,
+		"/v1/voice_clone":  ClassClone,
+		"/v1/voice_design": ClassClone,
+		"/v1/t2a_v2":       ClassTTS,
+		"/v1/t2a_async_v2": ClassTTS,
+		"/v1/files/list":   ClassQuery,
+		"/v1/get_voice":    ClassQuery,
+	} {
+		if got := classify(path); got != want {
+			t.Errorf("classify(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestUploadProgressStartsAfterRateLimitWait(t *testing.T) {
+	srv := newFakeServer(t)
+	path := writeSample(t)
+	clock := newFakeClock()
+	var waited bool
+	client := newTestClient(srv.URL, WithRateLimitObserver(func(EndpointClass, time.Duration) {
+		waited = true
+	}))
+	// 每分钟 12 次时桶容量为 1，用掉唯一的令牌后下一次上传需要排队 5 秒。
+	client.limiter = newRateLimiter(RateLimits{ClassUpload: 12}, clock.Now)
+	if err := client.wait(context.Background(), "/v1/files/upload"); err != nil {
+		t.Fatal(err)
+	}
+
+	var reports [][2]int64
+	progress := func(sent, total int64) {
+		reports = append(reports, [2]int64{sent, total})
+	}
+
+	// 排队期间被取消的上传从未开始发送，不应报告任何进度。
+	ctx, cancel := context.WithTimeout(WithProgress(context.Background(), progress), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.UploadFile(ctx, path, PurposeVoiceClone); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("UploadFile error = %v, want deadline exceeded", err)
+	}
+	if !waited || len(reports) != 0 {
+		t.Fatalf("waited %v, progress reported while queued: %v", waited, reports)
+	}
+
+	clock.Advance(5 * time.Second)
+	if _, err := client.UploadFile(WithProgress(context.Background(), progress), path, PurposeVoiceClone); err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	size := int64(len("sample audio"))
+	if len(reports) < 2 || reports[0] != [2]int64{0, size} || reports[len(reports)-1] != [2]int64{size, size} {
+		t.Errorf("progress reports = %v, want 0/%d through %d/%d", reports, size, size, size)
+	}
+}
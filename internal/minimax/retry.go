@@ -0,0 +1,247 @@
+package minimax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// maxRetryAfter 限制服务端 Retry-After 的最长等待，避免异常响应让批次长时间停滞。
+const maxRetryAfter = time.Minute
+
+// sendOncePaths 为重复发送会产生副作用的接口：每次成功调用都会创建一个音色。
+// 这类请求一旦发出，超时、连接中断与 5xx 都无法确定服务端是否已完成处理，因此不再重试；
+// 仅在服务端明确以限流拒绝，或请求尚未发出时才重试。
+var sendOncePaths = map[string]bool{
+	"/v1/voice_clone":  true,
+	"/v1/voice_design": true,
+}
+
+// RetryPolicy 控制请求在临时性失败（网络错误、5xx、限流）时的重试行为。
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter 为退避时长的随机抖动比例，取值 0~1。
+	Jitter float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		c.retry = policy
+	}
+}
+
+// backoff 返回第 attempt 次失败后的等待时长（attempt 从 1 开始）。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		rngMu.Lock()
+		factor := 1 + p.Jitter*(2*rng.Float64()-1)
+		rngMu.Unlock()
+		delay = time.Duration(float64(delay) * factor)
+	}
+	return delay
+}
+
+// retryDelay 返回第 attempt 次失败后实际等待的时长：服务端给出的 Retry-After 更长时以其为准，
+// 但不超过 maxRetryAfter。
+func (p RetryPolicy) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := p.backoff(attempt)
+	if retryAfter > delay {
+		delay = min(retryAfter, maxRetryAfter)
+	}
+	return delay
+}
+
+// AttemptError 记录请求最终失败前共尝试的次数。
+type AttemptError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *AttemptError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
+	}
+	return e.Err.Error()
+}
+
+func (e *AttemptError) Unwrap() error {
+	return e.Err
+}
+
+// Attempts 返回错误链中记录的尝试次数，未知时返回 0。
+func Attempts(err error) int {
+	var attemptErr *AttemptError
+	if errors.As(err, &attemptErr) {
+		return attemptErr.Attempts
+	}
+	return 0
+}
+
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+type BaseResp struct {
+	StatusCode int    `json:"status_code"`
+	StatusMsg  string `json:"status_msg"`
+}
+
+func (b BaseResp) baseResp() BaseResp {
+	return b
+}
+
+type apiResponse interface {
+	baseResp() BaseResp
+}
+
+// do 按重试策略执行请求，newRequest 在每次尝试时重新构造请求体。
+func (c *Client) do(ctx context.Context, op string, newRequest func(context.Context) (*http.Request, error), out apiResponse) (int, error) {
+	policy := c.retry
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest(ctx)
+		if err != nil {
+			return attempt, &AttemptError{Attempts: attempt, Err: err}
+		}
+		// 每次尝试（包括重试）都消耗一个令牌。
+		if err := c.wait(ctx, req.URL.Path); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return attempt, &AttemptError{Attempts: attempt, Err: err}
+		}
+
+		attemptCtx, cancel := c.withTimeout(ctx, classify(req.URL.Path))
+		sendOnce := sendOncePaths[req.URL.Path]
+		var sent atomic.Bool
+		if sendOnce {
+			attemptCtx = httptrace.WithClientTrace(attemptCtx, &httptrace.ClientTrace{
+				WroteRequest: func(info httptrace.WroteRequestInfo) {
+					if info.Err == nil {
+						sent.Store(true)
+					}
+				},
+			})
+		}
+		err = c.doOnce(ctx, req.WithContext(attemptCtx), op, out)
+		cancel()
+		if err == nil {
+			return attempt, nil
+		}
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return attempt, &AttemptError{Attempts: attempt, Err: err}
+		}
+		if sendOnce && sent.Load() && !errors.Is(retryable.err, ErrRateLimited) {
+			return attempt, &AttemptError{Attempts: attempt, Err: fmt.Errorf("%s not retried, the request may have been applied: %w", op, retryable.err)}
+		}
+		if attempt >= policy.MaxAttempts {
+			return attempt, &AttemptError{Attempts: attempt, Err: retryable.err}
+		}
+
+		timer := time.NewTimer(policy.retryDelay(attempt, retryable.retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, &AttemptError{Attempts: attempt, Err: fmt.Errorf("%s aborted while waiting to retry: %w", op, ctx.Err())}
+		case <-timer.C:
+		}
+	}
+}
+
+// doOnce 执行一次请求。req 携带单次请求的超时，ctx 为调用方的 context：
+// 仅当 ctx 本身结束时才视为不可重试，单次超时按网络错误重试。
+func (c *Client) doOnce(ctx context.Context, req *http.Request, op string, out apiResponse) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		err = fmt.Errorf("execute %s request: %w", op, err)
+		if ctx.Err() != nil {
+			return err
+		}
+		return &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &retryableError{err: fmt.Errorf("read %s response: %w", op, err)}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Op: op, HTTPStatus: resp.StatusCode, Body: string(body)}
+		if apiErr.Retryable() {
+			return &retryableError{err: apiErr, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
+		return apiErr
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode %s response: %w", op, err)
+	}
+
+	if base := out.baseResp(); base.StatusCode != 0 {
+		apiErr := &APIError{Op: op, HTTPStatus: resp.StatusCode, StatusCode: base.StatusCode, StatusMsg: base.StatusMsg}
+		if apiErr.Retryable() {
+			return &retryableError{err: apiErr, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
+		return apiErr
+	}
+
+	return nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,257 @@
+package minimax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scriptedReply 为测试服务端按顺序返回的一次响应。
+type scriptedReply struct {
+	status     int
+	body       string
+	retryAfter string
+}
+
+// newScriptedServer 依次返回 replies，用完后重复最后一个，并统计收到的请求数。
+func newScriptedServer(t *testing.T, replies ...scriptedReply) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		reply := replies[min(n, len(replies))-1]
+		if reply.retryAfter != "" {
+			w.Header().Set("Retry-After", reply.retryAfter)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(reply.status)
+		fmt.Fprint(w, reply.body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// newTestClient 返回指向 baseURL、关闭限速且重试间隔极短的客户端。
+func newTestClient(baseURL string, opts ...Option) *Client {
+	opts = append([]Option{
+		WithBaseURL(baseURL),
+		WithRateLimits(RateLimits{}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}),
+	}, opts...)
+	return NewClient("test-key", "test-group", opts...)
+}
+
+func baseRespBody(code int, msg string) string {
+	return fmt.Sprintf(`{"base_resp":{"status_code":%d,"status_msg":%q}}`, code, msg)
+}
+
+func TestRetryClassification(t *testing.T) {
+	ok := scriptedReply{status: http.StatusOK, body: baseRespBody(0, "success")}
+	tests := []struct {
+		name     string
+		replies  []scriptedReply
+		wantErr  error
+		wantCode string
+		attempts int
+	}{
+		{name: "success first try", replies: []scriptedReply{ok}, attempts: 1},
+		{name: "5xx then success", replies: []scriptedReply{{status: http.StatusBadGateway, body: "bad gateway"}, ok}, attempts: 2},
+		{name: "429 then success", replies: []scriptedReply{{status: http.StatusTooManyRequests}, ok}, attempts: 2},
+		{name: "rate limit status code then success", replies: []scriptedReply{{status: http.StatusOK, body: baseRespBody(1002, "rate limit")}, ok}, attempts: 2},
+		{name: "persistent 5xx exhausts attempts", replies: []scriptedReply{{status: http.StatusServiceUnavailable}}, wantErr: ErrServer, wantCode: "server_error", attempts: 3},
+		{name: "400 is not retried", replies: []scriptedReply{{status: http.StatusBadRequest, body: "bad request"}}, wantCode: "http_400", attempts: 1},
+		{name: "401 is not retried", replies: []scriptedReply{{status: http.StatusUnauthorized}}, wantErr: ErrInvalidCredentials, wantCode: "invalid_credentials", attempts: 1},
+		{name: "invalid credentials status code is not retried", replies: []scriptedReply{{status: http.StatusOK, body: baseRespBody(1004, "auth failed")}}, wantErr: ErrInvalidCredentials, wantCode: "invalid_credentials", attempts: 1},
+		{name: "insufficient balance is not retried", replies: []scriptedReply{{status: http.StatusOK, body: baseRespBody(1008, "insufficient balance")}}, wantErr: ErrInsufficientBalance, wantCode: "insufficient_balance", attempts: 1},
+		{name: "malformed json is not retried", replies: []scriptedReply{{status: http.StatusOK, body: "{"}}, wantCode: "local_error", attempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newScriptedServer(t, tt.replies...)
+			client := newTestClient(srv.URL)
+
+			_, err := client.GetVoices(context.Background(), VoiceTypeAll)
+			if got := int(calls.Load()); got != tt.attempts {
+				t.Errorf("server saw %d requests, want %d", got, tt.attempts)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error %v does not match %v", err, tt.wantErr)
+			}
+			if got := ErrorCode(err); got != tt.wantCode {
+				t.Errorf("ErrorCode = %q, want %q", got, tt.wantCode)
+			}
+			if got := Attempts(err); got != tt.attempts {
+				t.Errorf("Attempts(err) = %d, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestSendOnceRetries(t *testing.T) {
+	ok := scriptedReply{status: http.StatusOK, body: baseRespBody(0, "success")}
+	tests := []struct {
+		name     string
+		reply    scriptedReply
+		wantErr  error
+		attempts int
+	}{
+		// 限流说明服务端未处理请求，可以安全重发。
+		{name: "429", reply: scriptedReply{status: http.StatusTooManyRequests}, attempts: 2},
+		{name: "rate limit status code", reply: scriptedReply{status: http.StatusOK, body: baseRespBody(1002, "rate limit")}, attempts: 2},
+		{name: "5xx", reply: scriptedReply{status: http.StatusBadGateway, body: "bad gateway"}, wantErr: ErrServer, attempts: 1},
+		{name: "server error status code", reply: scriptedReply{status: http.StatusOK, body: baseRespBody(1000, "unknown error")}, wantErr: ErrServer, attempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newScriptedServer(t, tt.reply, ok)
+			client := newTestClient(srv.URL)
+
+			resp, err := client.CloneWithFileID(context.Background(), 1, "voice-test-01", CloneOptions{})
+			if got := int(calls.Load()); got != tt.attempts {
+				t.Errorf("server saw %d clone requests, want %d", got, tt.attempts)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || Attempts(err) != tt.attempts {
+					t.Errorf("error = %v (attempts %d), want %v", err, Attempts(err), tt.wantErr)
+				}
+				return
+			}
+			if err != nil || resp.Attempts != tt.attempts {
+				t.Fatalf("CloneWithFileID = %+v, %v", resp, err)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	srv, calls := newScriptedServer(t,
+		scriptedReply{status: http.StatusTooManyRequests, retryAfter: "1"},
+		scriptedReply{status: http.StatusOK, body: baseRespBody(0, "success")},
+	)
+	client := newTestClient(srv.URL)
+
+	start := time.Now()
+	if _, err := client.CloneWithFileID(context.Background(), 1, "voice-test-01", CloneOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server saw %d requests, want 2", calls.Load())
+	}
+}
+
+func TestRetryAbortsWhenContextCancelled(t *testing.T) {
+	srv, calls := newScriptedServer(t, scriptedReply{status: http.StatusServiceUnavailable, retryAfter: "30"})
+	client := newTestClient(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetVoices(ctx, VoiceTypeAll)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context deadline exceeded", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server saw %d requests, want 1", calls.Load())
+	}
+	if Attempts(err) != 1 {
+		t.Errorf("Attempts(err) = %d, want 1", Attempts(err))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if got := policy.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.2}
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := RetryPolicy{BaseDelay: policy.BaseDelay, MaxDelay: policy.MaxDelay}.backoff(attempt)
+		low := time.Duration(float64(base) * 0.8)
+		high := time.Duration(float64(base) * 1.2)
+		for i := 0; i < 200; i++ {
+			if got := policy.backoff(attempt); got < low || got > high {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", attempt, got, low, high)
+			}
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{name: "no retry-after uses backoff", attempt: 2, want: 200 * time.Millisecond},
+		{name: "shorter retry-after is ignored", attempt: 3, retryAfter: 50 * time.Millisecond, want: 400 * time.Millisecond},
+		{name: "longer retry-after wins", attempt: 1, retryAfter: 5 * time.Second, want: 5 * time.Second},
+		{name: "retry-after is capped", attempt: 1, retryAfter: time.Hour, want: maxRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.retryDelay(tt.attempt, tt.retryAfter); got != tt.want {
+				t.Errorf("retryDelay = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		// approx 表示与 want 相差 2 秒以内即可（HTTP 日期只精确到秒）。
+		approx bool
+	}{
+		{value: "", want: 0},
+		{value: "3", want: 3 * time.Second},
+		{value: "120", want: 120 * time.Second},
+		{value: "0", want: 0},
+		{value: "-5", want: 0},
+		{value: "soon", want: 0},
+		{value: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), want: 10 * time.Second, approx: true},
+		{value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), want: 0},
+	}
+	for _, tt := range tests {
+		got := parseRetryAfter(tt.value)
+		if tt.approx {
+			if diff := got - tt.want; diff < -2*time.Second || diff > 2*time.Second {
+				t.Errorf("parseRetryAfter(%q) = %s, want about %s", tt.value, got, tt.want)
+			}
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
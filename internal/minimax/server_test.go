@@ -0,0 +1,263 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"minimax/internal/minimax/minimaxserver"
+	"minimax/internal/minimax/minimaxtest"
+)
+
+func newFakeServer(t *testing.T) *minimaxtest.Server {
+	t.Helper()
+	srv := minimaxtest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeSample(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sample.mp3")
+	if err := os.WriteFile(path, []byte("sample audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestClientAgainstFakeServerClonePath(t *testing.T) {
+	srv := newFakeServer(t)
+	client := newTestClient(srv.URL)
+	ctx := context.Background()
+
+	upload, err := client.UploadFile(ctx, writeSample(t), PurposeVoiceClone)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if upload.Attempts != 1 || upload.File.Bytes != int64(len("sample audio")) {
+		t.Errorf("upload = %+v", upload)
+	}
+
+	resp, err := client.CloneWithFileID(ctx, upload.File.FileID, "voice-sample-01", CloneOptions{Text: "你好", Model: "speech-02-hd"})
+	if err != nil {
+		t.Fatalf("CloneWithFileID: %v", err)
+	}
+	if resp.DemoAudio == "" || resp.InputSensitive {
+		t.Errorf("clone response = %+v", resp)
+	}
+
+	demoPath := filepath.Join(t.TempDir(), "demo.mp3")
+	if _, err := client.Download(ctx, resp.DemoAudio, demoPath); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if data, _ := os.ReadFile(demoPath); !bytes.Equal(data, minimaxserver.Audio) {
+		t.Errorf("demo audio = %q", data)
+	}
+
+	voices, err := client.GetVoices(ctx, VoiceTypeCloning)
+	if err != nil {
+		t.Fatalf("GetVoices: %v", err)
+	}
+	if len(voices.Cloning) != 1 || voices.Cloning[0].VoiceID != "voice-sample-01" {
+		t.Errorf("cloned voices = %+v", voices.Cloning)
+	}
+
+	_, err = client.CloneWithFileID(ctx, upload.File.FileID, "voice-sample-01", CloneOptions{})
+	if !errors.Is(err, ErrDuplicateVoiceID) {
+		t.Errorf("second clone error = %v, want duplicate voice id", err)
+	}
+
+	if err := client.DeleteFile(ctx, upload.File.FileID, PurposeVoiceClone); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+	files, err := client.ListFiles(ctx, PurposeVoiceClone)
+	if err != nil || len(files) != 0 {
+		t.Errorf("ListFiles after delete = %v, %v", files, err)
+	}
+}
+
+func TestClientAgainstFakeServerFaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		fault        minimaxserver.Fault
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:         "rate limit recovers",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{StatusCode: 1002, StatusMsg: "rate limit exceeded", Times: 2},
+			wantAttempts: 3,
+		},
+		{
+			name:         "rate limit persists",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{StatusCode: 1002, StatusMsg: "rate limit exceeded"},
+			wantErr:      ErrRateLimited,
+			wantAttempts: 3,
+		},
+		{
+			name:         "http 429",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{HTTPStatus: 429, Times: 1},
+			wantAttempts: 2,
+		},
+		{
+			// 克隆请求已发出，5xx 无法说明音色是否已创建，不再重发。
+			name:         "5xx is not resent",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{HTTPStatus: 503, Times: 1},
+			wantErr:      ErrServer,
+			wantAttempts: 1,
+		},
+		{
+			name:         "5xx after the voice was created",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{HTTPStatus: 502, Times: 1, Handled: true},
+			wantErr:      ErrServer,
+			wantAttempts: 1,
+		},
+		{
+			name:         "insufficient balance is not retried",
+			path:         "/v1/voice_clone",
+			fault:        minimaxserver.Fault{StatusCode: 1008, StatusMsg: "insufficient balance"},
+			wantErr:      ErrInsufficientBalance,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			client := newTestClient(srv.URL)
+			ctx := context.Background()
+			upload, err := client.UploadFile(ctx, writeSample(t), PurposeVoiceClone)
+			if err != nil {
+				t.Fatalf("UploadFile: %v", err)
+			}
+
+			srv.InjectFault(tt.path, tt.fault)
+			resp, err := client.CloneWithFileID(ctx, upload.File.FileID, "voice-sample-01", CloneOptions{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if got := Attempts(err); got != tt.wantAttempts {
+					t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CloneWithFileID: %v", err)
+			}
+			if resp.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", resp.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClientAgainstFakeServerCloneTimeoutIsNotResent(t *testing.T) {
+	srv := newFakeServer(t)
+	client := newTestClient(srv.URL, WithTimeouts(Timeouts{ClassClone: 50 * time.Millisecond}))
+	ctx := context.Background()
+	upload, err := client.UploadFile(ctx, writeSample(t), PurposeVoiceClone)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+
+	// 服务端已创建音色，但响应晚于客户端超时；重发只会得到 2039。
+	srv.InjectFault("/v1/voice_clone", minimaxserver.Fault{Latency: time.Second, Times: 1, Handled: true})
+	_, err = client.CloneWithFileID(ctx, upload.File.FileID, "voice-sample-01", CloneOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrDuplicateVoiceID) {
+		t.Fatalf("error = %v, want the timeout", err)
+	}
+	if got := Attempts(err); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+	if ids := srv.VoiceIDs(); len(ids) != 1 || ids[0] != "voice-sample-01" {
+		t.Errorf("server voices = %v", ids)
+	}
+}
+
+func TestClientAgainstFakeServerDesignRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		fault        minimaxserver.Fault
+		wantErr      error
+		wantAttempts int
+		wantVoices   int
+	}{
+		{
+			name:         "rate limit is retried",
+			fault:        minimaxserver.Fault{StatusCode: 1002, StatusMsg: "rate limit exceeded", Times: 1},
+			wantAttempts: 2,
+			wantVoices:   1,
+		},
+		{
+			// 服务端已生成（并计费）音色，重发会再生成一个无人记录的音色。
+			name:         "5xx after the voice was designed",
+			fault:        minimaxserver.Fault{HTTPStatus: 502, Times: 1, Handled: true},
+			wantErr:      ErrServer,
+			wantAttempts: 1,
+			wantVoices:   1,
+		},
+		{
+			name:         "timeout after the voice was designed",
+			fault:        minimaxserver.Fault{Latency: time.Second, Times: 1, Handled: true},
+			wantErr:      context.DeadlineExceeded,
+			wantAttempts: 1,
+			wantVoices:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			srv.InjectFault("/v1/voice_design", tt.fault)
+			client := newTestClient(srv.URL, WithTimeouts(Timeouts{ClassClone: 50 * time.Millisecond}))
+
+			result, err := client.DesignVoice(context.Background(), DesignVoiceRequest{Prompt: "温柔的女声", PreviewText: "你好"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || Attempts(err) != tt.wantAttempts {
+					t.Errorf("error = %v (attempts %d), want %v after %d", err, Attempts(err), tt.wantErr, tt.wantAttempts)
+				}
+			} else if err != nil || result.Attempts != tt.wantAttempts {
+				t.Errorf("DesignVoice = %+v, %v; want %d attempts", result, err, tt.wantAttempts)
+			}
+			if got := len(srv.VoiceIDs()); got != tt.wantVoices {
+				t.Errorf("server created %d voices, want %d", got, tt.wantVoices)
+			}
+		})
+	}
+}
+
+func TestClientAgainstFakeServerUploadFault(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.InjectFault("/v1/files/upload", minimaxserver.Fault{HTTPStatus: 500, Times: 1})
+	client := newTestClient(srv.URL)
+
+	// 重试需重新发送完整的 multipart 正文，第二次上传的文件内容应完整。
+	upload, err := client.UploadFile(context.Background(), writeSample(t), PurposeVoiceClone)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if upload.Attempts != 2 || upload.File.Bytes != int64(len("sample audio")) {
+		t.Errorf("upload = %+v", upload)
+	}
+}
+
+func TestClientAgainstFakeServerLatency(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.InjectFault("/v1/get_voice", minimaxserver.Fault{Latency: 500 * time.Millisecond})
+	client := newTestClient(srv.URL, WithTimeouts(Timeouts{ClassQuery: 20 * time.Millisecond}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := client.GetVoices(context.Background(), VoiceTypeAll)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want a timeout", err)
+	}
+}
@@ -0,0 +1,230 @@
+package minimax
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// t2a_v2 流式响应中 data.status 的取值：1 为音频分片，2 为合成结束。
+// 结束事件会再次携带完整音频，流式写入时需跳过以免重复。
+const (
+	streamStatusChunk = 1
+	streamStatusDone  = 2
+)
+
+// StreamFormats 为流式合成支持的输出格式，wav 需要完整文件头，仅支持非流式。
+var StreamFormats = []string{"mp3", "flac", "pcm"}
+
+// SynthesizeStream 以 stream=true 调用 t2a_v2，边接收 SSE 分片边解码写入 w，
+// 返回结果中 Audio 为空。ctx 中的进度回调按已写入字节数报告，total 为 -1。
+// 音频一旦开始写入便无法安全重放，因此流式请求不做重试。
+func (c *Client) SynthesizeStream(ctx context.Context, req SynthesizeRequest, w io.Writer) (*SynthesizeResult, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	format := req.AudioSetting.Format
+	if format == "" {
+		format = DefaultAudioFormat
+	}
+	if !isStreamFormat(format) {
+		return nil, fmt.Errorf("synthesize stream: format %q is not supported, use one of %s", format, strings.Join(StreamFormats, "/"))
+	}
+	endpoint := c.endpoint("/v1/t2a_v2", url.Values{"GroupId": {c.groupID}})
+
+	payload := req.payload()
+	payload["stream"] = true
+	payload["output_format"] = "hex"
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	if err := c.wait(ctx, httpReq.URL.Path); err != nil {
+		return nil, err
+	}
+	resp, err := c.doStream(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Op: "synthesize stream", HTTPStatus: resp.StatusCode, Body: string(data)}
+	}
+
+	// 参数错误等情况下服务端直接返回 JSON 而不是事件流。
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+		var result t2aResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("decode synthesize stream response: %w", err)
+		}
+		if base := result.BaseResp; base.StatusCode != 0 {
+			return nil, &APIError{Op: "synthesize stream", HTTPStatus: resp.StatusCode, StatusCode: base.StatusCode, StatusMsg: base.StatusMsg}
+		}
+		return nil, fmt.Errorf("synthesize stream: expected event stream, got json")
+	}
+
+	progress := progressFrom(ctx)
+	if progress != nil {
+		progress(0, -1)
+	}
+
+	result := &SynthesizeResult{Format: format}
+	var written int64
+	err = readEvents(resp.Body, func(data []byte) error {
+		var event t2aResponse
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("decode stream event: %w", err)
+		}
+		if base := event.BaseResp; base.StatusCode != 0 {
+			return &APIError{Op: "synthesize stream", HTTPStatus: resp.StatusCode, StatusCode: base.StatusCode, StatusMsg: base.StatusMsg}
+		}
+		if event.TraceID != "" {
+			result.TraceID = event.TraceID
+		}
+
+		if event.Data.Status == streamStatusDone {
+			result.AudioLength = event.ExtraInfo.AudioLength
+			result.SampleRate = event.ExtraInfo.AudioSampleRate
+			if event.ExtraInfo.AudioFormat != "" {
+				result.Format = event.ExtraInfo.AudioFormat
+			}
+			return errStreamDone
+		}
+		if event.Data.Audio == "" {
+			return nil
+		}
+
+		chunk, err := hex.DecodeString(event.Data.Audio)
+		if err != nil {
+			return fmt.Errorf("decode audio chunk: %w", err)
+		}
+		n, err := w.Write(chunk)
+		written += int64(n)
+		if err != nil {
+			return fmt.Errorf("write audio chunk: %w", err)
+		}
+		if progress != nil {
+			progress(written, -1)
+		}
+		return nil
+	})
+	if errors.Is(err, errStreamDone) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("synthesize stream: connection closed before completion (%d bytes written)", written)
+}
+
+var errStreamDone = errors.New("stream done")
+
+// doStream 发出流式请求。ClassTTS 超时只约束到收到响应头为止：合成时长随文本增长，
+// 事件流本身不能受单次请求超时限制，否则长文本会在中途被截断。
+func (c *Client) doStream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	reqCtx, cancel := context.WithCancelCause(ctx)
+	var timer *time.Timer
+	if d := c.timeouts[ClassTTS]; d > 0 {
+		timer = time.AfterFunc(d, func() {
+			cancel(fmt.Errorf("no response headers within %s: %w", d, context.DeadlineExceeded))
+		})
+	}
+	resp, err := c.httpClient.Do(req.WithContext(reqCtx))
+	// 计时器已触发时，即便响应头恰好到达，读取响应体也会失败，按超时处理。
+	if timer != nil && !timer.Stop() && err == nil {
+		resp.Body.Close()
+		err = context.Cause(reqCtx)
+	}
+	if err != nil {
+		if cause := context.Cause(reqCtx); ctx.Err() == nil && cause != nil {
+			err = cause
+		}
+		cancel(nil)
+		return nil, fmt.Errorf("execute synthesize stream request: %w", err)
+	}
+	// reqCtx 需随响应体一同释放。
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: func() { cancel(nil) }}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func isStreamFormat(format string) bool {
+	for _, f := range StreamFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// readEvents 按 SSE 格式解析 r，对每个事件拼接后的 data 字段调用 fn，fn 返回错误时停止。
+// 音频分片的单行可能很长，因此不使用有行长上限的 bufio.Scanner。
+func readEvents(r io.Reader, fn func(data []byte) error) error {
+	reader := bufio.NewReader(r)
+	var data []byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read event stream: %w", err)
+		}
+		eof := err == io.EOF
+
+		line = bytes.TrimRight(line, "\r\n")
+		switch {
+		case len(line) == 0:
+			if len(data) > 0 {
+				if err := fn(data); err != nil {
+					return err
+				}
+				data = data[:0]
+			}
+		case bytes.HasPrefix(line, []byte("data:")):
+			value := bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" "))
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+		}
+
+		if eof {
+			if len(data) > 0 {
+				return fn(data)
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent 构造一条 t2a_v2 流式事件。
+func sseEvent(status int, audio []byte, extra string) string {
+	if extra == "" {
+		extra = "{}"
+	}
+	return fmt.Sprintf("data: {\"data\":{\"audio\":%q,\"status\":%d},\"extra_info\":%s,\"trace_id\":\"trace-1\",\"base_resp\":{\"status_code\":0,\"status_msg\":\"\"}}\n\n",
+		hex.EncodeToString(audio), status, extra)
+}
+
+func newStreamServer(t *testing.T, handler func(w http.ResponseWriter, flush func())) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/t2a_v2" || r.Header.Get("Accept") != "text/event-stream" {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, func() { w.(http.Flusher).Flush() })
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func streamRequest() SynthesizeRequest {
+	return SynthesizeRequest{Text: "你好", VoiceSetting: VoiceSetting{VoiceID: "voice-test-01"}}
+}
+
+func TestSynthesizeStream(t *testing.T) {
+	chunks := [][]byte{[]byte("ID3-frame-one"), []byte("frame-two"), []byte("frame-three")}
+	full := bytes.Join(chunks, nil)
+
+	srv := newStreamServer(t, func(w http.ResponseWriter, flush func()) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			fmt.Fprint(w, sseEvent(streamStatusChunk, chunk, ""))
+			flush()
+		}
+		// 结束事件再次携带完整音频，不应重复写入。
+		fmt.Fprint(w, sseEvent(streamStatusDone, full, `{"audio_length":1500,"audio_sample_rate":32000,"audio_format":"mp3"}`))
+	})
+
+	var out bytes.Buffer
+	var progress []int64
+	ctx := WithProgress(context.Background(), func(sent, total int64) {
+		if total != -1 {
+			t.Errorf("progress total = %d, want -1", total)
+		}
+		progress = append(progress, sent)
+	})
+	result, err := newTestClient(srv.URL).SynthesizeStream(ctx, streamRequest(), &out)
+	if err != nil {
+		t.Fatalf("SynthesizeStream: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), full) {
+		t.Errorf("wrote %q, want %q", out.Bytes(), full)
+	}
+	if result.AudioLength != 1500 || result.SampleRate != 32000 || result.Format != "mp3" || result.TraceID != "trace-1" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(progress) != len(chunks)+1 || progress[len(progress)-1] != int64(len(full)) {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestSynthesizeStreamJSONError(t *testing.T) {
+	srv := newStreamServer(t, func(w http.ResponseWriter, _ func()) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprint(w, baseRespBody(1004, "invalid api key"))
+	})
+
+	var out bytes.Buffer
+	_, err := newTestClient(srv.URL).SynthesizeStream(context.Background(), streamRequest(), &out)
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("error = %v, want invalid credentials", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("wrote %d bytes on error", out.Len())
+	}
+}
+
+func TestSynthesizeStreamEventError(t *testing.T) {
+	srv := newStreamServer(t, func(w http.ResponseWriter, flush func()) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sseEvent(streamStatusChunk, []byte("partial"), ""))
+		flush()
+		fmt.Fprint(w, "data: "+baseRespBody(1026, "sensitive")+"\n\n")
+	})
+
+	var out bytes.Buffer
+	_, err := newTestClient(srv.URL).SynthesizeStream(context.Background(), streamRequest(), &out)
+	if !errors.Is(err, ErrSensitiveContent) {
+		t.Fatalf("error = %v, want sensitive content", err)
+	}
+}
+
+func TestSynthesizeStreamTruncated(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(w http.ResponseWriter, flush func())
+		wantErr string
+	}{
+		{
+			name: "closed before done event",
+			handler: func(w http.ResponseWriter, flush func()) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, sseEvent(streamStatusChunk, []byte("chunk"), ""))
+			},
+			wantErr: "connection closed before completion (5 bytes written)",
+		},
+		{
+			name: "closed inside an event",
+			handler: func(w http.ResponseWriter, flush func()) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, sseEvent(streamStatusChunk, []byte("chunk"), ""))
+				fmt.Fprint(w, `data: {"data":{"audio":"abcd`)
+			},
+			wantErr: "decode stream event",
+		},
+		{
+			name: "connection dropped",
+			handler: func(w http.ResponseWriter, flush func()) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, sseEvent(streamStatusChunk, []byte("chunk"), ""))
+				flush()
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					conn.Close()
+				}
+			},
+			wantErr: "read event stream",
+		},
+		{
+			name: "invalid hex chunk",
+			handler: func(w http.ResponseWriter, flush func()) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, "data: {\"data\":{\"audio\":\"zz\",\"status\":1}}\n\n")
+			},
+			wantErr: "decode audio chunk",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newStreamServer(t, tt.handler)
+			var out bytes.Buffer
+			_, err := newTestClient(srv.URL).SynthesizeStream(context.Background(), streamRequest(), &out)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "single event", input: "data: one\n\n", want: []string{"one"}},
+		{name: "crlf and no space", input: "data:one\r\n\r\ndata: two\r\n\r\n", want: []string{"one", "two"}},
+		{name: "multi-line data", input: "data: a\ndata: b\n\n", want: []string{"a\nb"}},
+		{name: "other fields ignored", input: ": keep-alive\nevent: audio\nid: 3\ndata: x\n\n", want: []string{"x"}},
+		{name: "blank lines between events", input: "\n\ndata: x\n\n\n\ndata: y\n\n", want: []string{"x", "y"}},
+		{name: "last event without terminator", input: "data: x\n\ndata: y", want: []string{"x", "y"}},
+		{name: "long line", input: "data: " + strings.Repeat("ab", 1<<17) + "\n\n", want: []string{strings.Repeat("ab", 1<<17)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := readEvents(strings.NewReader(tt.input), func(data []byte) error {
+				got = append(got, string(data))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("readEvents: %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("events = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEventsStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := readEvents(strings.NewReader("data: 1\n\ndata: 2\n\n"), func([]byte) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("err = %v after %d calls", err, calls)
+	}
+}
+
+func TestSynthesizeStreamTimeoutOnlyCoversHeaders(t *testing.T) {
+	chunk := []byte("chunk")
+	srv := newStreamServer(t, func(w http.ResponseWriter, flush func()) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flush()
+		// 事件流总时长远超 TTS 超时，但响应头已及时返回。
+		for i := 0; i < 5; i++ {
+			time.Sleep(40 * time.Millisecond)
+			fmt.Fprint(w, sseEvent(streamStatusChunk, chunk, ""))
+			flush()
+		}
+		fmt.Fprint(w, sseEvent(streamStatusDone, nil, ""))
+	})
+
+	client := newTestClient(srv.URL, WithTimeouts(Timeouts{ClassTTS: 50 * time.Millisecond}))
+	var out bytes.Buffer
+	if _, err := client.SynthesizeStream(context.Background(), streamRequest(), &out); err != nil {
+		t.Fatalf("SynthesizeStream: %v", err)
+	}
+	if out.Len() != 5*len(chunk) {
+		t.Errorf("wrote %d bytes, want %d", out.Len(), 5*len(chunk))
+	}
+}
+
+func TestSynthesizeStreamHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := newStreamServer(t, func(w http.ResponseWriter, flush func()) {
+		<-release
+	})
+	defer close(release)
+
+	client := newTestClient(srv.URL, WithTimeouts(Timeouts{ClassTTS: 50 * time.Millisecond}))
+	start := time.Now()
+	_, err := client.SynthesizeStream(context.Background(), streamRequest(), io.Discard)
+	if !errors.Is(err, context.DeadlineExceeded) || ErrorCode(err) != "timeout" {
+		t.Fatalf("error = %v (code %q), want a timeout", err, ErrorCode(err))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timed out after %s", elapsed)
+	}
+}
@@ -0,0 +1,261 @@
+package minimax
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxTraceBody 为日志中保留的请求/响应正文字节数，超出部分截断。
+const maxTraceBody = 2048
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders 中的请求头只记录为 [REDACTED]。
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// secretKeyPattern 匹配疑似凭证的参数名，用于脱敏查询参数。Expires 与签名一同构成预签名链接
+// （如 OSS 的 OSSAccessKeyId、Signature、Expires），一并隐去。
+var secretKeyPattern = regexp.MustCompile(`(?i)(key|secret|token|password|signature|credential|expires)`)
+
+var secretJSONField = regexp.MustCompile(`(?i)("[^"]*(?:key|secret|token|password|signature|credential)[^"]*"\s*:\s*)"[^"]*"`)
+
+// urlPattern 匹配正文中的 http(s) 链接，JSON 中转义的斜杠与 \u0026 一并匹配，交由 redactURLString 处理。
+var urlPattern = regexp.MustCompile(`https?:(?:\\?/){2}[^\s"'<>]+`)
+
+var jsonURLEscapes = strings.NewReplacer(`\/`, "/", `\u0026`, "&", `\u003d`, "=", `\u003D`, "=")
+
+// WithTrace 开启请求追踪：每个 HTTP 请求与响应以结构化日志写入 logger，
+// 同一请求的记录共享 trace_id。凭证类请求头与参数会被脱敏，正文截断至 2 KB，
+// multipart 上传与音频等二进制正文不记录内容。
+func WithTrace(logger zerolog.Logger) Option {
+	return func(c *Client) {
+		c.trace = &logger
+	}
+}
+
+type traceTransport struct {
+	base   http.RoundTripper
+	logger zerolog.Logger
+}
+
+func newTraceTransport(base http.RoundTripper, logger zerolog.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &traceTransport{base: base, logger: logger}
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := newTraceID()
+	start := time.Now()
+
+	event := t.logger.Debug().
+		Str("trace_id", id).
+		Str("method", req.Method).
+		Str("url", redactURL(req.URL)).
+		Interface("headers", redactHeaders(req.Header)).
+		Int64("content_length", req.ContentLength)
+	if body, ok := requestBodySnippet(req); ok {
+		event = event.Str("body", body)
+	}
+	event.Msg("http request")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Debug().
+			Str("trace_id", id).
+			Dur("elapsed", time.Since(start)).
+			Err(err).
+			Msg("http request failed")
+		return nil, err
+	}
+
+	resp.Body = &traceBody{
+		ReadCloser: resp.Body,
+		logger:     t.logger,
+		id:         id,
+		start:      start,
+		header:     time.Since(start),
+		resp:       resp,
+		capture:    isTextual(resp.Header.Get("Content-Type")),
+	}
+	return resp, nil
+}
+
+// traceBody 在读取响应时保留开头的正文，并在 Close 时写出响应日志，
+// 因此记录的耗时覆盖了完整的下载或流式接收过程。
+type traceBody struct {
+	io.ReadCloser
+	logger  zerolog.Logger
+	id      string
+	start   time.Time
+	header  time.Duration
+	resp    *http.Response
+	capture bool
+
+	buf   bytes.Buffer
+	n     int64
+	once  sync.Once
+	rerr  error
+	close error
+}
+
+func (b *traceBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if b.capture && b.buf.Len() < maxTraceBody+1 {
+		rest := maxTraceBody + 1 - b.buf.Len()
+		b.buf.Write(p[:min(n, rest)])
+	}
+	if err != nil && err != io.EOF {
+		b.rerr = err
+	}
+	return n, err
+}
+
+func (b *traceBody) Close() error {
+	b.once.Do(func() {
+		b.close = b.ReadCloser.Close()
+		event := b.logger.Debug().
+			Str("trace_id", b.id).
+			Int("status", b.resp.StatusCode).
+			Interface("headers", redactHeaders(b.resp.Header)).
+			Dur("time_to_header", b.header).
+			Dur("elapsed", time.Since(b.start)).
+			Int64("bytes", b.n)
+		if b.capture {
+			event = event.Str("body", snippet(b.buf.Bytes()))
+		}
+		if b.rerr != nil {
+			event = event.AnErr("read_error", b.rerr)
+		}
+		event.Msg("http response")
+	})
+	return b.close
+}
+
+// requestBodySnippet 通过 GetBody 复制请求正文，不影响实际发送的内容。
+func requestBodySnippet(req *http.Request) (string, bool) {
+	if req.Body == nil || req.GetBody == nil || !isTextual(req.Header.Get("Content-Type")) {
+		return "", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxTraceBody+1))
+	if err != nil {
+		return "", false
+	}
+	return snippet(data), true
+}
+
+func snippet(data []byte) string {
+	truncated := len(data) > maxTraceBody
+	if truncated {
+		data = data[:maxTraceBody]
+	}
+	text := secretJSONField.ReplaceAllString(string(data), `$1"`+redacted+`"`)
+	// demo_audio、download_url 等字段名不含敏感词，但其中的预签名链接带有签名与 AccessKeyId。
+	text = redactURLs(text)
+	if truncated {
+		text += "...(truncated)"
+	}
+	return text
+}
+
+// isTextual 判断正文是否适合写入日志，multipart、音频等二进制内容返回 false。
+func isTextual(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			out[name] = redacted
+			continue
+		}
+		out[name] = redactURLs(strings.Join(values, ", "))
+	}
+	return out
+}
+
+// redactURLs 对 text 中出现的每个链接执行 redactURLString。
+func redactURLs(text string) string {
+	return urlPattern.ReplaceAllStringFunc(text, redactURLString)
+}
+
+// redactURLString 脱敏文本中的单个链接；无法解析时隐去整个查询串。
+func redactURLString(raw string) string {
+	raw = jsonURLEscapes.Replace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		if i := strings.IndexByte(raw, '?'); i >= 0 {
+			return raw[:i+1] + redacted
+		}
+		return raw
+	}
+	return redactURL(u)
+}
+
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	copied := *u
+	copied.User = nil
+	query := copied.Query()
+	changed := false
+	for name := range query {
+		if secretKeyPattern.MatchString(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		copied.RawQuery = query.Encode()
+	}
+	return copied.String()
+}
+
+func newTraceID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return time.Now().Format("150405.000000000")
+	}
+	return hex.EncodeToString(b[:])
+}
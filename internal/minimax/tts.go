@@ -0,0 +1,195 @@
+package minimax
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	DefaultAudioFormat = "mp3"
+	DefaultSampleRate  = 32000
+	// MaxTimbreWeights 是 timbre_weights 允许混合的最大音色数。
+	MaxTimbreWeights = 4
+)
+
+var (
+	// Emotions 为 voice_setting.emotion 支持的取值，空字符串表示由模型自动判断。
+	Emotions = []string{"", "happy", "sad", "angry", "fearful", "disgusted", "surprised", "calm"}
+	// AudioFormats 为非流式合成支持的输出格式。
+	AudioFormats = []string{"mp3", "wav", "flac", "pcm"}
+	SampleRates  = []int{8000, 16000, 22050, 24000, 32000, 44100}
+)
+
+type VoiceSetting struct {
+	VoiceID string  `json:"voice_id,omitempty"`
+	Speed   float64 `json:"speed,omitempty"`
+	Vol     float64 `json:"vol,omitempty"`
+	Pitch   int     `json:"pitch,omitempty"`
+	Emotion string  `json:"emotion,omitempty"`
+}
+
+type AudioSetting struct {
+	SampleRate int    `json:"sample_rate,omitempty"`
+	Bitrate    int    `json:"bitrate,omitempty"`
+	Format     string `json:"format,omitempty"`
+	Channel    int    `json:"channel,omitempty"`
+}
+
+// TimbreWeight 为混合音色中的一个成分，Weight 取值 1~100，按各成分权重的比例混合。
+type TimbreWeight struct {
+	VoiceID string `json:"voice_id"`
+	Weight  int    `json:"weight"`
+}
+
+// SynthesizeRequest 描述一次 t2a_v2 合成请求，零值字段使用服务端默认值。
+// 设置 TimbreWeights 时使用混合音色，VoiceSetting.VoiceID 可留空。
+type SynthesizeRequest struct {
+	Model         string
+	Text          string
+	VoiceSetting  VoiceSetting
+	AudioSetting  AudioSetting
+	TimbreWeights []TimbreWeight
+}
+
+type SynthesizeResult struct {
+	Audio       []byte
+	Format      string
+	AudioLength int64 // 毫秒
+	SampleRate  int
+	TraceID     string
+}
+
+type t2aResponse struct {
+	Data struct {
+		Audio  string `json:"audio"`
+		Status int    `json:"status"`
+	} `json:"data"`
+	ExtraInfo struct {
+		AudioLength     int64  `json:"audio_length"`
+		AudioSampleRate int    `json:"audio_sample_rate"`
+		AudioSize       int64  `json:"audio_size"`
+		AudioFormat     string `json:"audio_format"`
+	} `json:"extra_info"`
+	TraceID  string `json:"trace_id"`
+	BaseResp `json:"base_resp"`
+}
+
+// Validate 检查参数是否在 t2a_v2 接口允许的范围内。
+func (r SynthesizeRequest) Validate() error {
+	if strings.TrimSpace(r.Text) == "" {
+		return fmt.Errorf("synthesize: text is required")
+	}
+	if len(r.TimbreWeights) > 0 {
+		if err := validateTimbreWeights(r.TimbreWeights); err != nil {
+			return err
+		}
+	} else if r.VoiceSetting.VoiceID == "" {
+		return fmt.Errorf("synthesize: voice_id is required")
+	}
+	if s := r.VoiceSetting.Speed; s != 0 && (s < 0.5 || s > 2) {
+		return fmt.Errorf("synthesize: speed %.2f out of range [0.5, 2]", s)
+	}
+	// 音量为 0 表示未设置，发送时按默认值 1 处理。
+	if v := r.VoiceSetting.Vol; v < 0 || v > 10 {
+		return fmt.Errorf("synthesize: volume %.2f out of range [0, 10] (0 uses the default)", v)
+	}
+	if p := r.VoiceSetting.Pitch; p < -12 || p > 12 {
+		return fmt.Errorf("synthesize: pitch %d out of range [-12, 12]", p)
+	}
+	return nil
+}
+
+func validateTimbreWeights(weights []TimbreWeight) error {
+	if len(weights) > MaxTimbreWeights {
+		return fmt.Errorf("synthesize: at most %d timbre weights allowed, got %d", MaxTimbreWeights, len(weights))
+	}
+	seen := make(map[string]bool, len(weights))
+	for _, w := range weights {
+		if w.VoiceID == "" {
+			return fmt.Errorf("synthesize: timbre weight voice_id is required")
+		}
+		if seen[w.VoiceID] {
+			return fmt.Errorf("synthesize: duplicate timbre voice_id %s", w.VoiceID)
+		}
+		seen[w.VoiceID] = true
+		if w.Weight < 1 || w.Weight > 100 {
+			return fmt.Errorf("synthesize: weight %d for %s out of range [1, 100]", w.Weight, w.VoiceID)
+		}
+	}
+	return nil
+}
+
+func (r SynthesizeRequest) payload() map[string]any {
+	model := r.Model
+	if model == "" {
+		model = DefaultModel
+	}
+	audio := r.AudioSetting
+	if audio.Format == "" {
+		audio.Format = DefaultAudioFormat
+	}
+	if audio.SampleRate == 0 {
+		audio.SampleRate = DefaultSampleRate
+	}
+	voice := r.VoiceSetting
+	if voice.Speed == 0 {
+		voice.Speed = 1
+	}
+	if voice.Vol == 0 {
+		voice.Vol = 1
+	}
+	payload := map[string]any{
+		"model":         model,
+		"text":          r.Text,
+		"voice_setting": voice,
+		"audio_setting": audio,
+	}
+	if len(r.TimbreWeights) > 0 {
+		payload["timbre_weights"] = r.TimbreWeights
+	}
+	return payload
+}
+
+// Synthesize 调用 t2a_v2 同步合成语音，返回解码后的音频数据。
+func (c *Client) Synthesize(ctx context.Context, req SynthesizeRequest) (*SynthesizeResult, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	endpoint := c.endpoint("/v1/t2a_v2", url.Values{"GroupId": {c.groupID}})
+
+	payload := req.payload()
+	payload["stream"] = false
+	payload["output_format"] = "hex"
+
+	var result t2aResponse
+	if _, err := c.postJSON(ctx, "synthesize", endpoint, payload, &result); err != nil {
+		return nil, err
+	}
+
+	audio, err := hex.DecodeString(result.Data.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("decode synthesized audio: %w", err)
+	}
+
+	format := result.ExtraInfo.AudioFormat
+	if format == "" {
+		format = req.AudioSetting.Format
+	}
+	if format == "" {
+		format = DefaultAudioFormat
+	}
+
+	return &SynthesizeResult{
+		Audio:       audio,
+		Format:      format,
+		AudioLength: result.ExtraInfo.AudioLength,
+		SampleRate:  result.ExtraInfo.AudioSampleRate,
+		TraceID:     result.TraceID,
+	}, nil
+}
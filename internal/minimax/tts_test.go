@@ -0,0 +1,61 @@
+package minimax
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSynthesizeRequestValidate(t *testing.T) {
+	valid := func(mutate func(*SynthesizeRequest)) SynthesizeRequest {
+		req := SynthesizeRequest{Text: "你好", VoiceSetting: VoiceSetting{VoiceID: "voice-test-01"}}
+		mutate(&req)
+		return req
+	}
+	tests := []struct {
+		name    string
+		req     SynthesizeRequest
+		wantErr string
+	}{
+		{name: "defaults", req: valid(func(*SynthesizeRequest) {})},
+		{name: "empty text", req: valid(func(r *SynthesizeRequest) { r.Text = "  " }), wantErr: "text is required"},
+		{name: "missing voice", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.VoiceID = "" }), wantErr: "voice_id is required"},
+		{name: "speed too low", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Speed = 0.4 }), wantErr: "speed"},
+		{name: "speed upper bound", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Speed = 2 })},
+		{name: "volume unset", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Vol = 0 })},
+		{name: "volume upper bound", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Vol = 10 })},
+		{name: "volume negative", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Vol = -1 }), wantErr: "volume -1.00 out of range [0, 10]"},
+		{name: "volume too high", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Vol = 10.5 }), wantErr: "volume 10.50 out of range [0, 10]"},
+		{name: "pitch too high", req: valid(func(r *SynthesizeRequest) { r.VoiceSetting.Pitch = 13 }), wantErr: "pitch"},
+		{name: "timbre weights without voice", req: valid(func(r *SynthesizeRequest) {
+			r.VoiceSetting.VoiceID = ""
+			r.TimbreWeights = []TimbreWeight{{VoiceID: "a-voice-01", Weight: 30}, {VoiceID: "b-voice-01", Weight: 70}}
+		})},
+		{name: "duplicate timbre", req: valid(func(r *SynthesizeRequest) {
+			r.TimbreWeights = []TimbreWeight{{VoiceID: "a-voice-01", Weight: 30}, {VoiceID: "a-voice-01", Weight: 70}}
+		}), wantErr: "duplicate timbre"},
+		{name: "timbre weight out of range", req: valid(func(r *SynthesizeRequest) {
+			r.TimbreWeights = []TimbreWeight{{VoiceID: "a-voice-01", Weight: 0}}
+		}), wantErr: "out of range [1, 100]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSynthesizePayloadDefaultsVolume(t *testing.T) {
+	payload := SynthesizeRequest{Text: "你好", VoiceSetting: VoiceSetting{VoiceID: "voice-test-01"}}.payload()
+	if got := payload["voice_setting"].(VoiceSetting).Vol; got != 1 {
+		t.Errorf("unset volume sent as %v, want 1", got)
+	}
+}
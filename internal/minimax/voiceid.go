@@ -0,0 +1,312 @@
+package minimax
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// voice_id 的长度限制（字符数）。
+const (
+	MinVoiceIDLength = 8
+	MaxVoiceIDLength = 256
+)
+
+const (
+	DefaultVoiceIDPrefix = "minimax-voice-"
+	// DefaultHashLength 为哈希策略默认截取的十六进制位数，48 位足以让批量样本间的碰撞可以忽略。
+	DefaultHashLength = 12
+)
+
+// 可在配置中选择的 voice_id 生成策略。
+const (
+	VoiceIDStrategyHash     = "hash"
+	VoiceIDStrategySlug     = "slug"
+	VoiceIDStrategyTemplate = "template"
+	VoiceIDStrategyManifest = "manifest"
+)
+
+// ValidateVoiceID 按 MiniMax 的规则检查 voice_id：长度 8~256，以字母开头，
+// 仅含字母、数字、- 与 _，且不能以 - 或 _ 结尾。
+func ValidateVoiceID(id string) error {
+	switch {
+	case len(id) < MinVoiceIDLength || len(id) > MaxVoiceIDLength:
+		return fmt.Errorf("%w: %q must be %d-%d characters", ErrInvalidVoiceID, id, MinVoiceIDLength, MaxVoiceIDLength)
+	case !isLetter(id[0]):
+		return fmt.Errorf("%w: %q must start with a letter", ErrInvalidVoiceID, id)
+	case id[len(id)-1] == '-' || id[len(id)-1] == '_':
+		return fmt.Errorf("%w: %q must not end with - or _", ErrInvalidVoiceID, id)
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; !isLetter(c) && !isDigit(c) && c != '-' && c != '_' {
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidVoiceID, id, c)
+		}
+	}
+	return nil
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// VoiceIDStrategy 为待克隆的文件生成 voice_id，seq 为文件在批次中的序号（从 1 开始）。
+type VoiceIDStrategy interface {
+	VoiceID(path string, seq int) (string, error)
+}
+
+// HashStrategy 以文件内容 MD5 的前 Length 位十六进制作为后缀，同一文件总是得到同一 ID。
+type HashStrategy struct {
+	Prefix string
+	// Length 为 0 时取 DefaultHashLength，超过 32 时取完整哈希。
+	Length int
+}
+
+func (s HashStrategy) VoiceID(path string, _ int) (string, error) {
+	sum, err := fileMD5(path)
+	if err != nil {
+		return "", err
+	}
+	length := s.Length
+	if length <= 0 {
+		length = DefaultHashLength
+	}
+	length = min(length, len(sum))
+	return finishVoiceID(orDefault(s.Prefix, DefaultVoiceIDPrefix) + sum[:length])
+}
+
+// SlugStrategy 由文件名生成可读的 ID，例如 “Narrator 01.mp3” → “narrator-01”。
+// 结果不以字母开头时加 voice- 前缀；文件名过短或不含 ASCII 字母数字时追加内容哈希，保证满足长度要求。
+type SlugStrategy struct {
+	Prefix string
+}
+
+func (s SlugStrategy) VoiceID(path string, _ int) (string, error) {
+	slug := Slugify(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	id := s.Prefix + slug
+	if id == "" || !isLetter(id[0]) {
+		id = "voice-" + id
+	}
+	if slug == "" || len(strings.TrimRight(id, "-_")) < MinVoiceIDLength {
+		sum, err := fileMD5(path)
+		if err != nil {
+			return "", err
+		}
+		id = strings.TrimRight(id, "-_") + "-" + sum[:6]
+	}
+	return finishVoiceID(id)
+}
+
+// TemplateStrategy 按模板生成 ID，支持以下占位符：
+//
+//	{name}  文件名 slug
+//	{date}  当前日期，格式 20060102
+//	{n}     批次内序号，补零到三位，从 Start 开始计数
+//	{hash}  文件内容 MD5 的前 8 位
+type TemplateStrategy struct {
+	Template string
+	// Start 为 {n} 的起始值，0 表示从 1 开始。
+	Start int
+	// Now 为 nil 时使用 time.Now。
+	Now func() time.Time
+}
+
+func (s TemplateStrategy) VoiceID(path string, seq int) (string, error) {
+	if strings.TrimSpace(s.Template) == "" {
+		return "", fmt.Errorf("%w: empty template", ErrInvalidVoiceID)
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	start := s.Start
+	if start == 0 {
+		start = 1
+	}
+
+	replacements := []string{
+		"{name}", Slugify(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))),
+		"{date}", now().Format("20060102"),
+		"{n}", fmt.Sprintf("%03d", start+seq-1),
+	}
+	if strings.Contains(s.Template, "{hash}") {
+		sum, err := fileMD5(path)
+		if err != nil {
+			return "", err
+		}
+		replacements = append(replacements, "{hash}", sum[:8])
+	}
+	return finishVoiceID(strings.NewReplacer(replacements...).Replace(s.Template))
+}
+
+// ManifestStrategy 从清单中查找每个文件的 ID，键为绝对路径或文件名；
+// 清单中没有的文件交给 Fallback 处理，Fallback 为 nil 时返回错误。
+type ManifestStrategy struct {
+	IDs      map[string]string
+	Fallback VoiceIDStrategy
+}
+
+func (s ManifestStrategy) VoiceID(path string, seq int) (string, error) {
+	keys := []string{path, filepath.Base(path)}
+	if abs, err := filepath.Abs(path); err == nil {
+		keys = append([]string{abs}, keys...)
+	}
+	for _, key := range keys {
+		if id, ok := s.IDs[key]; ok {
+			return id, ValidateVoiceID(id)
+		}
+	}
+	if s.Fallback != nil {
+		return s.Fallback.VoiceID(path, seq)
+	}
+	return "", fmt.Errorf("manifest has no voice id for %s", filepath.Base(path))
+}
+
+// LoadManifest 读取两列 CSV 清单（文件路径或文件名, voice_id），首行为 file,voice_id 表头时跳过。
+// 相对路径按清单所在目录解析。
+func LoadManifest(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	dir := filepath.Dir(path)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	ids := make(map[string]string)
+	for line := 1; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+		if len(row) < 2 {
+			return nil, fmt.Errorf("manifest line %d: expected file,voice_id", line)
+		}
+		file, id := strings.TrimSpace(row[0]), strings.TrimSpace(row[1])
+		if line == 1 && strings.EqualFold(id, "voice_id") {
+			continue
+		}
+		if err := ValidateVoiceID(id); err != nil {
+			return nil, fmt.Errorf("manifest line %d: %w", line, err)
+		}
+		if strings.ContainsRune(file, filepath.Separator) || strings.Contains(file, "/") {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(dir, file)
+			}
+			file = filepath.Clean(file)
+		}
+		ids[file] = id
+	}
+	return ids, nil
+}
+
+// Slugify 将文本转为仅含小写字母、数字与 - 的片段，其余字符合并为单个 -。
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isLetter(c):
+			b.WriteByte(c | 0x20)
+			dash = false
+		case isDigit(c):
+			b.WriteByte(c)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// UniqueVoiceID 在 id 已被占用时依次追加 -2、-3 … 直至得到未占用的 ID。
+func UniqueVoiceID(id string, taken map[string]bool) string {
+	if !taken[id] {
+		return id
+	}
+	for n := 2; ; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		candidate := id
+		if len(candidate)+len(suffix) > MaxVoiceIDLength {
+			candidate = candidate[:MaxVoiceIDLength-len(suffix)]
+		}
+		candidate += suffix
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// ExistingVoiceIDs 返回账户下已存在的全部 voice_id（含系统音色），用于克隆前的冲突检查。
+func ExistingVoiceIDs(ctx context.Context, vm VoiceManager) (map[string]bool, error) {
+	list, err := vm.GetVoices(ctx, VoiceTypeAll)
+	if err != nil {
+		return nil, fmt.Errorf("list existing voices: %w", err)
+	}
+	ids := make(map[string]bool)
+	for _, v := range list.All() {
+		ids[v.VoiceID] = true
+	}
+	return ids, nil
+}
+
+// GenerateVoiceID 使用默认哈希策略为文件生成 voice_id。
+func GenerateVoiceID(path string) (string, error) {
+	return HashStrategy{}.VoiceID(path, 1)
+}
+
+func finishVoiceID(id string) (string, error) {
+	if len(id) > MaxVoiceIDLength {
+		id = id[:MaxVoiceIDLength]
+	}
+	id = strings.TrimRight(id, "-_")
+	if err := ValidateVoiceID(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func fileMD5(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve path for hash: %w", err)
+	}
+
+	file, err := os.Open(absPath)
+	if err != nil {
+		return "", fmt.Errorf("open file for hash: %w", err)
+	}
+	defer file.Close()
+
+	hasher := md5.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("hash file: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
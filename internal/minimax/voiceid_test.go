@@ -0,0 +1,311 @@
+package minimax
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeVoiceFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateVoiceID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr string
+	}{
+		{id: "voice001"},
+		{id: "Voice_01-a"},
+		{id: "v" + strings.Repeat("a", MaxVoiceIDLength-1)},
+		{id: "voice01", wantErr: "must be 8-256 characters"},
+		{id: "", wantErr: "must be 8-256 characters"},
+		{id: "v" + strings.Repeat("a", MaxVoiceIDLength), wantErr: "must be 8-256 characters"},
+		{id: "1voice-01", wantErr: "must start with a letter"},
+		{id: "-voice-01", wantErr: "must start with a letter"},
+		{id: "voice-01-", wantErr: "must not end with - or _"},
+		{id: "voice-01_", wantErr: "must not end with - or _"},
+		{id: "voice 001", wantErr: "invalid character"},
+		{id: "voice.001", wantErr: "invalid character"},
+		{id: "voice旁白01", wantErr: "invalid character"},
+	}
+	for _, tt := range tests {
+		err := ValidateVoiceID(tt.id)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateVoiceID(%q) = %v", tt.id, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidVoiceID) || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateVoiceID(%q) = %v, want %q", tt.id, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	for in, want := range map[string]string{
+		"Narrator 01":       "narrator-01",
+		"  Hello__World!! ": "hello-world",
+		"旁白":                "",
+		"旁白 Narrator":       "narrator",
+		"第1集_主角":            "1",
+		"Café Olé":          "caf-ol",
+		"---":               "",
+		"ABC-123":           "abc-123",
+	} {
+		if got := Slugify(in); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSlugStrategy(t *testing.T) {
+	dir := t.TempDir()
+	hashSuffix := regexp.MustCompile(`-[0-9a-f]{6}$`)
+	tests := []struct {
+		name   string
+		file   string
+		prefix string
+		want   string
+		hashed bool
+	}{
+		{name: "readable name", file: "Narrator 01.mp3", want: "narrator-01"},
+		{name: "prefix", file: "Narrator 01.mp3", prefix: "team-", want: "team-narrator-01"},
+		{name: "leading digit", file: "01 Intro Voice.mp3", want: "voice-01-intro-voice"},
+		{name: "cjk only", file: "旁白.mp3", want: "voice", hashed: true},
+		{name: "too short", file: "ab.mp3", want: "ab", hashed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeVoiceFile(t, dir, tt.file, tt.name)
+			id, err := SlugStrategy{Prefix: tt.prefix}.VoiceID(path, 1)
+			if err != nil {
+				t.Fatalf("VoiceID: %v", err)
+			}
+			if tt.hashed {
+				if !hashSuffix.MatchString(id) || strings.TrimSuffix(id, id[len(id)-7:]) != tt.want {
+					t.Errorf("VoiceID = %q, want %s-<hash>", id, tt.want)
+				}
+			} else if id != tt.want {
+				t.Errorf("VoiceID = %q, want %q", id, tt.want)
+			}
+			if err := ValidateVoiceID(id); err != nil {
+				t.Errorf("generated invalid id: %v", err)
+			}
+		})
+	}
+}
+
+func TestHashStrategy(t *testing.T) {
+	dir := t.TempDir()
+	a := writeVoiceFile(t, dir, "a.mp3", "same")
+	b := writeVoiceFile(t, dir, "sub/b.mp3", "same")
+
+	idA, err := HashStrategy{}.VoiceID(a, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idB, _ := HashStrategy{}.VoiceID(b, 2)
+	if idA != idB || len(idA) != len(DefaultVoiceIDPrefix)+DefaultHashLength {
+		t.Errorf("ids %q and %q", idA, idB)
+	}
+	full, _ := HashStrategy{Prefix: "v-", Length: 64}.VoiceID(a, 1)
+	if len(full) != len("v-")+32 {
+		t.Errorf("full hash id %q", full)
+	}
+}
+
+func TestTemplateStrategy(t *testing.T) {
+	dir := t.TempDir()
+	path := writeVoiceFile(t, dir, "Narrator 01.mp3", "audio")
+	now := func() time.Time { return time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC) }
+	sum, _ := fileMD5(path)
+
+	tests := []struct {
+		name     string
+		template string
+		start    int
+		seq      int
+		want     string
+		wantErr  bool
+	}{
+		{name: "all placeholders", template: "team-{date}-{n}-{name}-{hash}", seq: 2, want: "team-20250309-002-narrator-01-" + sum[:8]},
+		{name: "custom start", template: "team-{n}-{name}", start: 10, seq: 3, want: "team-012-narrator-01"},
+		{name: "trailing separator trimmed", template: "narrator-{n}_", seq: 1, want: "narrator-001"},
+		{name: "leading digit", template: "{n}-{name}", seq: 1, wantErr: true},
+		{name: "too short", template: "v{n}", seq: 1, wantErr: true},
+		{name: "empty template", template: " ", seq: 1, wantErr: true},
+		{name: "truncated to max length", template: "v" + strings.Repeat("x", MaxVoiceIDLength) + "-{n}", seq: 1, want: "v" + strings.Repeat("x", MaxVoiceIDLength-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := TemplateStrategy{Template: tt.template, Start: tt.start, Now: now}.VoiceID(path, tt.seq)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidVoiceID) {
+					t.Errorf("VoiceID = %q, %v; want invalid voice id", id, err)
+				}
+				return
+			}
+			if err != nil || id != tt.want {
+				t.Errorf("VoiceID = %q, %v; want %q", id, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadManifestAndLookup(t *testing.T) {
+	dir := t.TempDir()
+	manifest := writeVoiceFile(t, dir, "ids.csv", strings.Join([]string{
+		"file,voice_id",
+		"# 注释行会被忽略",
+		"clips/narrator.mp3, voice-narrator",
+		"intro.mp3,voice-intro-01",
+		"/abs/outro.mp3,voice-outro-01",
+		"",
+	}, "\n"))
+
+	ids, err := LoadManifest(manifest)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	absDir, _ := filepath.Abs(dir)
+	want := map[string]string{
+		filepath.Join(absDir, "clips", "narrator.mp3"): "voice-narrator",
+		"intro.mp3":      "voice-intro-01",
+		"/abs/outro.mp3": "voice-outro-01",
+	}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v", ids)
+	}
+	for k, v := range want {
+		if ids[k] != v {
+			t.Errorf("ids[%q] = %q, want %q", k, ids[k], v)
+		}
+	}
+
+	fallback := HashStrategy{Prefix: "fallback-"}
+	strategy := ManifestStrategy{IDs: ids, Fallback: fallback}
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		// 带目录的条目只匹配完整路径，同名文件在其他目录下不会误用该 ID。
+		{name: "full path", path: writeVoiceFile(t, dir, "clips/narrator.mp3", "a"), want: "voice-narrator"},
+		{name: "same name elsewhere", path: writeVoiceFile(t, dir, "other/narrator.mp3", "b")},
+		// 仅写文件名的条目匹配任意目录下的同名文件。
+		{name: "file name", path: writeVoiceFile(t, dir, "any/where/intro.mp3", "c"), want: "voice-intro-01"},
+		{name: "unlisted", path: writeVoiceFile(t, dir, "extra.mp3", "d")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := strategy.VoiceID(tt.path, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want == "" {
+				want, _ = fallback.VoiceID(tt.path, 1)
+			}
+			if id != want {
+				t.Errorf("VoiceID = %q, want %q", id, want)
+			}
+		})
+	}
+
+	if _, err := (ManifestStrategy{IDs: ids}).VoiceID(filepath.Join(dir, "extra.mp3"), 1); err == nil {
+		t.Error("unlisted file without fallback should fail")
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing column", content: "a.mp3\n", wantErr: "manifest line 1: expected file,voice_id"},
+		{name: "invalid id", content: "file,voice_id\na.mp3,1bad-voice-id\n", wantErr: "manifest line 2"},
+		{name: "trailing separator", content: "a.mp3,voice-aaaa-01\nb.mp3,voice-bbbb-\n", wantErr: "manifest line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeVoiceFile(t, dir, tt.name+".csv", tt.content)
+			if _, err := LoadManifest(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadManifest error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := LoadManifest(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("missing manifest should fail")
+	}
+}
+
+func TestUniqueVoiceID(t *testing.T) {
+	long := "v" + strings.Repeat("a", MaxVoiceIDLength-1)
+	tests := []struct {
+		name  string
+		id    string
+		taken []string
+		want  string
+	}{
+		{name: "free", id: "voice-narrator", want: "voice-narrator"},
+		{name: "taken once", id: "voice-narrator", taken: []string{"voice-narrator"}, want: "voice-narrator-2"},
+		{name: "taken twice", id: "voice-narrator", taken: []string{"voice-narrator", "voice-narrator-2"}, want: "voice-narrator-3"},
+		{name: "at max length", id: long, taken: []string{long}, want: long[:MaxVoiceIDLength-2] + "-2"},
+		{
+			name:  "at max length twice",
+			id:    long,
+			taken: []string{long, long[:MaxVoiceIDLength-2] + "-2"},
+			want:  long[:MaxVoiceIDLength-2] + "-3",
+		},
+		{
+			name:  "two digit suffix",
+			id:    long,
+			taken: append([]string{long}, suffixed(long, 2, 9)...),
+			want:  long[:MaxVoiceIDLength-3] + "-10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taken := make(map[string]bool)
+			for _, id := range tt.taken {
+				taken[id] = true
+			}
+			got := UniqueVoiceID(tt.id, taken)
+			if got != tt.want {
+				t.Errorf("UniqueVoiceID = %q, want %q", got, tt.want)
+			}
+			if err := ValidateVoiceID(got); err != nil {
+				t.Errorf("unique id invalid: %v", err)
+			}
+		})
+	}
+}
+
+// suffixed 返回 UniqueVoiceID 为 id 依次生成的第 from~to 个候选。
+func suffixed(id string, from, to int) []string {
+	var out []string
+	for n := from; n <= to; n++ {
+		taken := map[string]bool{id: true}
+		for _, prev := range out {
+			taken[prev] = true
+		}
+		out = append(out, UniqueVoiceID(id, taken))
+	}
+	return out
+}
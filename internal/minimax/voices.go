@@ -0,0 +1,93 @@
+package minimax
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	VoiceTypeSystem     = "system"
+	VoiceTypeCloning    = "voice_cloning"
+	VoiceTypeGeneration = "voice_generation"
+	VoiceTypeAll        = "all"
+)
+
+type Voice struct {
+	VoiceID     string   `json:"voice_id"`
+	VoiceName   string   `json:"voice_name,omitempty"`
+	Description []string `json:"description,omitempty"`
+	CreatedTime string   `json:"created_time,omitempty"`
+	// Type 由客户端按返回分组填充，取值为 VoiceType* 常量。
+	Type string `json:"-"`
+}
+
+// VoiceList 按类型分组保存 get_voice 接口返回的音色。
+type VoiceList struct {
+	System     []Voice `json:"system_voice"`
+	Cloning    []Voice `json:"voice_cloning"`
+	Generation []Voice `json:"voice_generation"`
+	BaseResp   `json:"base_resp"`
+}
+
+// All 返回所有分组的音色，并填充 Type 字段。
+func (l *VoiceList) All() []Voice {
+	voices := make([]Voice, 0, len(l.System)+len(l.Cloning)+len(l.Generation))
+	for _, group := range []struct {
+		voiceType string
+		voices    []Voice
+	}{
+		{VoiceTypeSystem, l.System},
+		{VoiceTypeCloning, l.Cloning},
+		{VoiceTypeGeneration, l.Generation},
+	} {
+		for _, v := range group.voices {
+			v.Type = group.voiceType
+			voices = append(voices, v)
+		}
+	}
+	return voices
+}
+
+type deleteVoiceResponse struct {
+	VoiceID     string `json:"voice_id"`
+	CreatedTime string `json:"created_time"`
+	BaseResp    `json:"base_resp"`
+}
+
+// GetVoices 查询账户下可用的音色，voiceType 为空时按 VoiceTypeAll 查询。
+func (c *Client) GetVoices(ctx context.Context, voiceType string) (*VoiceList, error) {
+	if c.apiKey == "" || c.groupID == "" {
+		return nil, ErrMissingCredentials
+	}
+	if voiceType == "" {
+		voiceType = VoiceTypeAll
+	}
+	endpoint := c.endpoint("/v1/get_voice", url.Values{"GroupId": {c.groupID}})
+
+	var result VoiceList
+	if _, err := c.postJSON(ctx, "get voices", endpoint, map[string]any{"voice_type": voiceType}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteVoice 删除克隆或设计生成的音色，voiceType 取 VoiceTypeCloning 或 VoiceTypeGeneration。
+func (c *Client) DeleteVoice(ctx context.Context, voiceType, voiceID string) error {
+	if c.apiKey == "" || c.groupID == "" {
+		return ErrMissingCredentials
+	}
+	if voiceType != VoiceTypeCloning && voiceType != VoiceTypeGeneration {
+		return fmt.Errorf("delete voice: unsupported voice type %q", voiceType)
+	}
+	endpoint := c.endpoint("/v1/delete_voice", url.Values{"GroupId": {c.groupID}})
+
+	payload := map[string]any{
+		"voice_type": voiceType,
+		"voice_id":   voiceID,
+	}
+
+	var result deleteVoiceResponse
+	_, err := c.postJSON(ctx, "delete voice", endpoint, payload, &result)
+	return err
+}
@@ -12,6 +12,8 @@ type Paths struct {
 	DataDir      string
 	LogsDir      string
 	LogFile      string
+	DemosDir     string
+	TTSDir       string
 	DBFile       string
 	DownloadsDir string
 }
@@ -33,6 +35,8 @@ func ResolvePaths() (Paths, error) {
 		DataDir:      dataDir,
 		LogsDir:      logsDir,
 		LogFile:      filepath.Join(logsDir, "app.log"),
+		DemosDir:     filepath.Join(dataDir, "demos"),
+		TTSDir:       filepath.Join(dataDir, "tts"),
 		DBFile:       filepath.Join(dataDir, "minimax.db"),
 		DownloadsDir: downloadsDir,
 	}, nil
@@ -43,6 +47,8 @@ func EnsureDirs(paths Paths) error {
 		paths.ConfigDir,
 		paths.DataDir,
 		paths.LogsDir,
+		paths.DemosDir,
+		paths.TTSDir,
 	}
 
 	for _, dir := range dirs {